@@ -0,0 +1,156 @@
+package event
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types and the latest version of their payload
+const (
+	PaymentStatusChanged              = "payment.status.changed"
+	PaymentStatusChangedLatestVersion = 2
+
+	OrderCreated             = "order.created"
+	OrderPaid                = "order.paid"
+	OrderCancelled           = "order.cancelled"
+	OrderExpired             = "order.expired"
+	OrderRefunded            = "order.refunded"
+	OrderOccurrenceCancelled = "order.occurrence-cancelled"
+	OrderLatestVersion       = 1
+
+	FieldScheduleBooked        = "field.schedule.booked"
+	FieldScheduleBookingFailed = "field.schedule.booking-failed"
+	FieldLatestVersion         = 1
+)
+
+const envelopeSchema = "envelope"
+
+var (
+	ErrNotEnvelope        = errors.New("message is not an event envelope")
+	ErrUnsupportedVersion = errors.New("event type or version is not supported")
+)
+
+// Versioned envelope of every inter-service message, the payload is checked
+// against the JSON Schema of its type and version
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	Producer      string          `json:"producer"`
+	CorrelationID string          `json:"correlationId"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// Schemas keyed by the file name without extension, e.g. payment.status.changed.v1
+var schemas = loadSchemas()
+
+func loadSchemas() map[string]*Schema {
+	entries, err := schemaFiles.ReadDir("schemas")
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := make(map[string]*Schema, len(entries))
+	for _, entry := range entries {
+		data, err := schemaFiles.ReadFile("schemas/" + entry.Name())
+		if err != nil {
+			panic(err)
+		}
+
+		var schema Schema
+		err = json.Unmarshal(data, &schema)
+		if err != nil {
+			panic(fmt.Errorf("invalid event schema %s: %w", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = &schema
+	}
+	return loaded
+}
+
+func payloadSchema(eventType string, version int) (*Schema, error) {
+	schema, ok := schemas[fmt.Sprintf("%s.v%d", eventType, version)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s v%d", ErrUnsupportedVersion, eventType, version)
+	}
+	return schema, nil
+}
+
+func validate(schema *Schema, data []byte, path string) error {
+	var value any
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	return schema.Validate(value, path)
+}
+
+// Build the envelope of the payload and return the encoded message
+func New(eventType string, version int, producer string, correlationID string, payload any) ([]byte, error) {
+	schema, err := payloadSchema(eventType, version)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	err = validate(schema, payloadJSON, "payload")
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s v%d event: %w", eventType, version, err)
+	}
+
+	return json.Marshal(Envelope{
+		ID:            uuid.New().String(),
+		Type:          eventType,
+		Version:       version,
+		OccurredAt:    time.Now(),
+		Producer:      producer,
+		CorrelationID: correlationID,
+		Payload:       payloadJSON,
+	})
+}
+
+// Decode the message and check both the envelope and the payload against their schema,
+// a message sent before the envelope was introduced returns ErrNotEnvelope
+func Decode(data []byte) (*Envelope, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fields["version"]; !ok {
+		return nil, ErrNotEnvelope
+	}
+
+	err = validate(schemas[envelopeSchema], data, "envelope")
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope Envelope
+	err = json.Unmarshal(data, &envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := payloadSchema(envelope.Type, envelope.Version)
+	if err != nil {
+		return nil, err
+	}
+	err = validate(schema, envelope.Payload, "payload")
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s v%d event %s: %w", envelope.Type, envelope.Version, envelope.ID, err)
+	}
+	return &envelope, nil
+}
@@ -0,0 +1,111 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type orderPayload struct {
+	OrderID          string   `json:"orderID"`
+	Code             string   `json:"code"`
+	UserID           string   `json:"userID"`
+	Amount           float64  `json:"amount"`
+	Status           string   `json:"status"`
+	FieldScheduleIDs []string `json:"fieldScheduleIDs"`
+}
+
+func TestSchemasLoad(t *testing.T) {
+	if _, ok := schemas[envelopeSchema]; !ok {
+		t.Fatalf("envelope schema is not loaded")
+	}
+
+	for _, eventType := range []string{OrderCreated, OrderPaid, OrderCancelled, OrderExpired, OrderRefunded, OrderOccurrenceCancelled} {
+		if _, err := payloadSchema(eventType, OrderLatestVersion); err != nil {
+			t.Errorf("payloadSchema(%s) error = %v", eventType, err)
+		}
+	}
+}
+
+func TestNewAndDecode(t *testing.T) {
+	payload := orderPayload{
+		OrderID:          "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d",
+		Code:             "ORD-00001",
+		UserID:           "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+		Amount:           150000,
+		Status:           "payment-success",
+		FieldScheduleIDs: []string{"1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e"},
+	}
+
+	message, err := New(OrderPaid, OrderLatestVersion, "order-service", payload.OrderID, payload)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	envelope, err := Decode(message)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if envelope.Type != OrderPaid || envelope.Version != OrderLatestVersion || envelope.CorrelationID != payload.OrderID {
+		t.Errorf("Decode() = %+v", envelope)
+	}
+
+	var decoded orderPayload
+	err = json.Unmarshal(envelope.Payload, &decoded)
+	if err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if decoded.Code != payload.Code || decoded.Amount != payload.Amount {
+		t.Errorf("payload = %+v, want %+v", decoded, payload)
+	}
+}
+
+func TestNewRejectsInvalidPayload(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		version   int
+		payload   any
+		wantErr   error
+	}{
+		{
+			name:      "unknown version",
+			eventType: OrderPaid,
+			version:   OrderLatestVersion + 1,
+			payload:   orderPayload{},
+			wantErr:   ErrUnsupportedVersion,
+		},
+		{
+			name:      "unknown type",
+			eventType: "order.unknown",
+			version:   1,
+			payload:   orderPayload{},
+			wantErr:   ErrUnsupportedVersion,
+		},
+		{
+			name:      "invalid payload",
+			eventType: OrderPaid,
+			version:   OrderLatestVersion,
+			payload:   orderPayload{OrderID: "not-a-uuid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.eventType, tt.version, "order-service", "", tt.payload)
+			if err == nil {
+				t.Fatalf("New() error = nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("New() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeWithoutEnvelope(t *testing.T) {
+	_, err := Decode([]byte(`{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "status": "settlement"}`))
+	if !errors.Is(err, ErrNotEnvelope) {
+		t.Errorf("Decode() error = %v, want %v", err, ErrNotEnvelope)
+	}
+}
@@ -0,0 +1,200 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnsupportedKeyword = errors.New("schema keyword is not supported")
+
+// Keywords the validator implements, the annotations are ignored
+var supportedKeywords = []string{
+	"$schema", "$id", "title", "description",
+	"type", "properties", "required", "additionalProperties", "items", "enum", "format", "minimum", "minLength",
+}
+
+var supportedFormats = []string{"", "uuid", "date-time"}
+
+// Subset of JSON Schema used by the event schemas:
+// type, properties, required, additionalProperties, items, enum, format, minimum and minLength.
+// A schema with any other keyword or format fails to load instead of being half checked.
+type Schema struct {
+	Type                 SchemaType         `json:"type"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Enum                 []any              `json:"enum"`
+	Format               string             `json:"format"`
+	Minimum              *float64           `json:"minimum"`
+	MinLength            *int               `json:"minLength"`
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var keywords map[string]json.RawMessage
+	err := json.Unmarshal(data, &keywords)
+	if err != nil {
+		return err
+	}
+	for keyword := range keywords {
+		if !slices.Contains(supportedKeywords, keyword) {
+			return fmt.Errorf("%w: %s", ErrUnsupportedKeyword, keyword)
+		}
+	}
+
+	// Decoded without this method, the nested schemas are still checked
+	type plainSchema Schema
+	err = json.Unmarshal(data, (*plainSchema)(s))
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(supportedFormats, s.Format) {
+		return fmt.Errorf("%w: format %s", ErrUnsupportedKeyword, s.Format)
+	}
+	return nil
+}
+
+// A single type name or a list of them
+type SchemaType []string
+
+func (s *SchemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = SchemaType{single}
+		return nil
+	}
+
+	var list []string
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// Validate the decoded JSON value, the path is used in the error message
+func (s *Schema) Validate(value any, path string) error {
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(name string) bool {
+		return s.isType(value, name)
+	}) {
+		return fmt.Errorf("%s must be of type %v", path, []string(s.Type))
+	}
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		if !s.inEnum(v) {
+			return fmt.Errorf("%s must be one of %v", path, s.Enum)
+		}
+		return s.validateString(v, path)
+	case float64:
+		if !s.inEnum(v) {
+			return fmt.Errorf("%s must be one of %v", path, s.Enum)
+		}
+		if s.Minimum != nil && v < *s.Minimum {
+			return fmt.Errorf("%s must be at least %v", path, *s.Minimum)
+		}
+	case map[string]any:
+		return s.validateObject(v, path)
+	case []any:
+		return s.validateArray(v, path)
+	}
+	return nil
+}
+
+// Only scalar values are compared with the enum
+func (s *Schema) inEnum(value any) bool {
+	return len(s.Enum) == 0 || slices.Contains(s.Enum, value)
+}
+
+func (s *Schema) isType(value any, name string) bool {
+	switch name {
+	case "null":
+		return value == nil
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	}
+	return false
+}
+
+func (s *Schema) validateString(value string, path string) error {
+	if s.MinLength != nil && len(value) < *s.MinLength {
+		return fmt.Errorf("%s must be at least %d characters", path, *s.MinLength)
+	}
+
+	switch s.Format {
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			return fmt.Errorf("%s must be a uuid", path)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("%s must be a RFC 3339 date-time", path)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(value map[string]any, path string) error {
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			return fmt.Errorf("%s.%s is required", path, name)
+		}
+	}
+
+	for name, property := range value {
+		schema, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return fmt.Errorf("%s.%s is not allowed", path, name)
+			}
+			continue
+		}
+
+		err := schema.Validate(property, path+"."+name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateArray(value []any, path string) error {
+	if s.Items == nil {
+		return nil
+	}
+
+	for i, item := range value {
+		err := s.Items.Validate(item, fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"required": ["orderID", "amount"],
+		"additionalProperties": false,
+		"properties": {
+			"orderID": { "type": "string", "format": "uuid" },
+			"amount": { "type": "number", "minimum": 0 },
+			"status": { "type": "string", "enum": ["pending", "settlement"] },
+			"code": { "type": "string", "minLength": 1 },
+			"paidAt": { "type": ["string", "null"], "format": "date-time" },
+			"fieldScheduleIDs": { "type": "array", "items": { "type": "string", "format": "uuid" } }
+		}
+	}`)
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			value: `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": 150000, "status": "pending"}`,
+		},
+		{
+			name:  "nullable date-time",
+			value: `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": 0, "paidAt": null}`,
+		},
+		{
+			name:  "date-time",
+			value: `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": 0, "paidAt": "2025-01-02T15:04:05Z"}`,
+		},
+		{
+			name:    "missing required property",
+			value:   `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d"}`,
+			wantErr: true,
+		},
+		{
+			name:    "additional property",
+			value:   `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": 1, "extra": true}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong type",
+			value:   `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": "1"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid uuid",
+			value:   `{"orderID": "not-a-uuid", "amount": 1}`,
+			wantErr: true,
+		},
+		{
+			name:    "below minimum",
+			value:   `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": -1}`,
+			wantErr: true,
+		},
+		{
+			name:    "not in enum",
+			value:   `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": 1, "status": "unknown"}`,
+			wantErr: true,
+		},
+		{
+			name:    "too short",
+			value:   `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": 1, "code": ""}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid date-time",
+			value:   `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": 1, "paidAt": "yesterday"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid array item",
+			value:   `{"orderID": "8f5c0f4e-1b7a-4c1e-9d59-0b3f7a1b2c3d", "amount": 1, "fieldScheduleIDs": ["x"]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(schema, []byte(tt.value), "payload")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaUnsupportedKeyword(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{
+			name:   "annotations only",
+			schema: `{"$schema": "https://json-schema.org/draft/2020-12/schema", "$id": "a", "title": "b", "description": "c"}`,
+		},
+		{
+			name:    "ref",
+			schema:  `{"$ref": "other.json"}`,
+			wantErr: true,
+		},
+		{
+			name:    "oneOf",
+			schema:  `{"oneOf": [{"type": "string"}, {"type": "number"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "nested pattern",
+			schema:  `{"type": "object", "properties": {"code": {"type": "string", "pattern": "^[A-Z]+$"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "nested maximum",
+			schema:  `{"type": "array", "items": {"type": "number", "maximum": 10}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			schema:  `{"type": "string", "format": "email"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema Schema
+			err := json.Unmarshal([]byte(tt.schema), &schema)
+			if tt.wantErr && !errors.Is(err, ErrUnsupportedKeyword) {
+				t.Errorf("Unmarshal() error = %v, want %v", err, ErrUnsupportedKeyword)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Unmarshal() error = %v", err)
+			}
+		})
+	}
+}
+
+func mustSchema(t *testing.T, data string) *Schema {
+	t.Helper()
+	var schema Schema
+	err := json.Unmarshal([]byte(data), &schema)
+	if err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+	return &schema
+}
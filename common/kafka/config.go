@@ -0,0 +1,14 @@
+package kafka
+
+import "time"
+
+// Settings of the consumer group, the dead letter topic and the producer, taken from the config of the service
+type Config struct {
+	// Attempts to handle a message before it is moved to the dead letter topic, and retries of the producer
+	MaxRetry int
+	// Timeout of the producer and of reading the dead letter topic, the default one when zero
+	Timeout time.Duration
+	// Delay before the second attempt to handle a message, doubled on every attempt up to MaxBackOff
+	BackOff    time.Duration
+	MaxBackOff time.Duration
+}
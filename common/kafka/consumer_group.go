@@ -0,0 +1,126 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+type (
+	TopicName string
+	Handler   func(ctx context.Context, message *sarama.ConsumerMessage) error
+)
+
+type ConsumerGroup struct {
+	config     Config
+	handler    map[TopicName]Handler
+	deadLetter IDeadLetter
+}
+
+func NewConsumerGroup(config Config, deadLetter IDeadLetter) *ConsumerGroup {
+	return &ConsumerGroup{
+		config:     config,
+		handler:    make(map[TopicName]Handler),
+		deadLetter: deadLetter,
+	}
+}
+
+// All of this func for implementing sarama ConsumerGroupHandler interface (Setup, Cleanup, ConsumeClaim)
+func (c *ConsumerGroup) Setup(sarama sarama.ConsumerGroupSession) error {
+	logrus.Infof("Setup consumer group")
+	return nil
+}
+
+func (c *ConsumerGroup) Cleanup(sarama sarama.ConsumerGroupSession) error {
+	logrus.Infof("Cleanup consumer group")
+	return nil
+}
+
+func (c *ConsumerGroup) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	messages := claim.Messages()
+	for message := range messages {
+		handler, ok := c.handler[TopicName(message.Topic)]
+		if !ok {
+			logrus.Errorf("handler for topic %s not found", message.Topic)
+			continue
+		}
+
+		// Retry consume with exponential backoff if the data from Kafka is error
+		var err error
+		maxRetry := c.config.MaxRetry
+		if maxRetry <= 0 {
+			maxRetry = 1
+		}
+		for attempt := 1; attempt <= maxRetry; attempt++ {
+			err = c.handle(session.Context(), handler, message)
+			if err == nil {
+				break
+			}
+
+			logrus.Errorf("error handling message on %s, attempt %d: %v", message.Topic, attempt, err)
+			if attempt == maxRetry {
+				break
+			}
+
+			select {
+			case <-session.Context().Done():
+				// Rebalance or shutdown, the message is consumed again by the next session
+				return nil
+			case <-time.After(c.backoff(attempt)):
+			}
+		}
+
+		if err != nil {
+			// Poison message, move it to the dead letter topic so the partition keeps moving
+			dlqErr := c.deadLetter.Publish(message, err, maxRetry)
+			if errors.Is(dlqErr, ErrDeadLetterTopicNotConfigured) {
+				logrus.Errorf("max retry reached and no dead letter topic, message will be ignored")
+			} else if dlqErr != nil {
+				logrus.Errorf("failed to publish message to dead letter topic: %v", dlqErr)
+				return dlqErr
+			}
+		}
+		session.MarkMessage(message, time.Now().UTC().String())
+	}
+	return nil
+}
+
+// Run the handler, a panic is turned into an error so the message is retried instead of dropped
+func (c *ConsumerGroup) handle(ctx context.Context, handler Handler, message *sarama.ConsumerMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered from panic: %v", r)
+		}
+	}()
+	return handler(ctx, message)
+}
+
+// Delay before the next attempt, doubled on every attempt
+func (c *ConsumerGroup) backoff(attempt int) time.Duration {
+	backoff := c.config.BackOff
+	if backoff <= 0 {
+		backoff = 100 * time.Millisecond
+	}
+	maxBackoff := c.config.MaxBackOff
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Second
+	}
+
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// Register Handler
+func (c *ConsumerGroup) RegisterHandler(topic TopicName, handler Handler) {
+	c.handler[topic] = handler
+	logrus.Infof("register handler for topic %s", topic)
+}
@@ -0,0 +1,306 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+// Headers added to a message published to the dead letter topic
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderError             = "x-error"
+	HeaderAttempts          = "x-attempts"
+	HeaderFailedAt          = "x-failed-at"
+)
+
+var ErrDeadLetterTopicNotConfigured = errors.New("dead letter topic is not configured")
+
+// How long the topic is waited for the next message when reading it
+const readTimeout = 10 * time.Second
+
+type DeadLetter struct {
+	config   Config
+	brokers  []string
+	topic    string
+	mutex    sync.Mutex
+	producer sarama.SyncProducer
+}
+
+type DeadLetterMessage struct {
+	Partition     int32
+	Offset        int64
+	OriginalTopic string
+	Error         string
+	Attempts      string
+	FailedAt      string
+	Key           []byte
+	Value         []byte
+}
+
+type IDeadLetter interface {
+	Publish(*sarama.ConsumerMessage, error, int) error
+	List(int) ([]DeadLetterMessage, error)
+	Replay(int32, int64) error
+	ReplayAll() (int, error)
+	Close() error
+}
+
+func NewDeadLetter(config Config, brokers []string, topic string) IDeadLetter {
+	return &DeadLetter{config: config, brokers: brokers, topic: topic}
+}
+
+// The producer is created on first use and then kept open for the next messages
+func (d *DeadLetter) getProducer() (sarama.SyncProducer, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.producer != nil {
+		return d.producer, nil
+	}
+
+	kafkaConfig := sarama.NewConfig()
+	kafkaConfig.Producer.Return.Successes = true
+	kafkaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	kafkaConfig.Producer.Retry.Max = d.config.MaxRetry
+	producer, err := sarama.NewSyncProducer(d.brokers, kafkaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	d.producer = producer
+	return d.producer, nil
+}
+
+func (d *DeadLetter) produce(message *sarama.ProducerMessage) error {
+	producer, err := d.getProducer()
+	if err != nil {
+		return err
+	}
+
+	_, _, err = producer.SendMessage(message)
+	return err
+}
+
+func (d *DeadLetter) Close() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.producer == nil {
+		return nil
+	}
+	err := d.producer.Close()
+	d.producer = nil
+	return err
+}
+
+// Publish the poison message with the error metadata in the headers
+func (d *DeadLetter) Publish(message *sarama.ConsumerMessage, cause error, attempts int) error {
+	if d.topic == "" {
+		return ErrDeadLetterTopicNotConfigured
+	}
+
+	headers := []sarama.RecordHeader{
+		{Key: []byte(HeaderOriginalTopic), Value: []byte(message.Topic)},
+		{Key: []byte(HeaderOriginalPartition), Value: []byte(strconv.Itoa(int(message.Partition)))},
+		{Key: []byte(HeaderOriginalOffset), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		{Key: []byte(HeaderError), Value: []byte(cause.Error())},
+		{Key: []byte(HeaderAttempts), Value: []byte(strconv.Itoa(attempts))},
+		{Key: []byte(HeaderFailedAt), Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	}
+
+	err := d.produce(&sarama.ProducerMessage{
+		Topic:   d.topic,
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.Warnf("message %s/%d/%d moved to dead letter topic %s", message.Topic, message.Partition, message.Offset, d.topic)
+	return nil
+}
+
+// Read the messages of the dead letter topic, from the oldest one
+func (d *DeadLetter) List(limit int) ([]DeadLetterMessage, error) {
+	messages := make([]DeadLetterMessage, 0)
+	err := d.read(func(message *sarama.ConsumerMessage) bool {
+		messages = append(messages, d.toDeadLetterMessage(message))
+		return limit <= 0 || len(messages) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// Replay a single message back to its original topic
+func (d *DeadLetter) Replay(partition int32, offset int64) error {
+	var found *DeadLetterMessage
+	err := d.read(func(message *sarama.ConsumerMessage) bool {
+		if message.Partition == partition && message.Offset == offset {
+			deadLetterMessage := d.toDeadLetterMessage(message)
+			found = &deadLetterMessage
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if found == nil {
+		return fmt.Errorf("message %d/%d not found on %s", partition, offset, d.topic)
+	}
+	return d.replay(found)
+}
+
+// Replay every message of the dead letter topic back to its original topic
+func (d *DeadLetter) ReplayAll() (int, error) {
+	messages, err := d.List(0)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, message := range messages {
+		err = d.replay(&message)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(messages), nil
+}
+
+func (d *DeadLetter) replay(message *DeadLetterMessage) error {
+	topic := message.OriginalTopic
+	if topic == "" {
+		return fmt.Errorf("message %d/%d has no original topic", message.Partition, message.Offset)
+	}
+
+	err := d.produce(&sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.ByteEncoder(message.Key),
+		Value: sarama.ByteEncoder(message.Value),
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("message %d/%d replayed to %s", message.Partition, message.Offset, topic)
+	return nil
+}
+
+// Read the topic up to the current newest offset, stop when the callback returns false
+func (d *DeadLetter) read(callback func(*sarama.ConsumerMessage) bool) error {
+	kafkaConfig := sarama.NewConfig()
+	kafkaConfig.Consumer.Return.Errors = true
+	client, err := sarama.NewClient(d.brokers, kafkaConfig)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	partitions, err := consumer.Partitions(d.topic)
+	if err != nil {
+		return err
+	}
+
+	for _, partition := range partitions {
+		oldest, err := client.GetOffset(d.topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return err
+		}
+		newest, err := client.GetOffset(d.topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return err
+		}
+		if oldest >= newest {
+			continue
+		}
+
+		stopped, err := d.readPartition(consumer, partition, oldest, newest, callback)
+		if err != nil || stopped {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read the partition from the oldest offset up to the newest one, true when the callback stopped the read
+func (d *DeadLetter) readPartition(
+	consumer sarama.Consumer,
+	partition int32,
+	oldest int64,
+	newest int64,
+	callback func(*sarama.ConsumerMessage) bool,
+) (bool, error) {
+	partitionConsumer, err := consumer.ConsumePartition(d.topic, partition, oldest)
+	if err != nil {
+		return false, err
+	}
+	defer partitionConsumer.Close()
+
+	timeout := readTimeout
+	if d.config.Timeout > 0 {
+		timeout = d.config.Timeout
+	}
+
+	for {
+		select {
+		case message, ok := <-partitionConsumer.Messages():
+			if !ok {
+				return false, fmt.Errorf("partition %d of %s is closed before offset %d", partition, d.topic, newest-1)
+			}
+			if !callback(message) {
+				return true, nil
+			}
+			if message.Offset >= newest-1 {
+				return false, nil
+			}
+		case consumerErr, ok := <-partitionConsumer.Errors():
+			if !ok {
+				return false, fmt.Errorf("partition %d of %s is closed before offset %d", partition, d.topic, newest-1)
+			}
+			return false, consumerErr
+		case <-time.After(timeout):
+			return false, fmt.Errorf("timed out reading partition %d of %s", partition, d.topic)
+		}
+	}
+}
+
+func (d *DeadLetter) toDeadLetterMessage(message *sarama.ConsumerMessage) DeadLetterMessage {
+	deadLetterMessage := DeadLetterMessage{
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		Key:       message.Key,
+		Value:     message.Value,
+	}
+
+	for _, header := range message.Headers {
+		switch string(header.Key) {
+		case HeaderOriginalTopic:
+			deadLetterMessage.OriginalTopic = string(header.Value)
+		case HeaderError:
+			deadLetterMessage.Error = string(header.Value)
+		case HeaderAttempts:
+			deadLetterMessage.Attempts = string(header.Value)
+		case HeaderFailedAt:
+			deadLetterMessage.FailedAt = string(header.Value)
+		}
+	}
+	return deadLetterMessage
+}
@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+type Producer struct {
+	config   Config
+	brokers  []string
+	mutex    sync.Mutex
+	producer sarama.SyncProducer
+}
+
+type IProducer interface {
+	ProduceMessage(string, string, []byte) error
+}
+
+func NewKafkaProducer(config Config, brokers []string) IProducer {
+	return &Producer{config: config, brokers: brokers}
+}
+
+// The producer is created on first use and then kept open,
+// so the service can start while the broker is down
+func (p *Producer) getProducer() (sarama.SyncProducer, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.producer != nil {
+		return p.producer, nil
+	}
+
+	kafkaConfig := sarama.NewConfig()
+	kafkaConfig.Producer.Return.Successes = true
+	kafkaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	kafkaConfig.Producer.Retry.Max = p.config.MaxRetry
+	// Keyed messages of the same order always land on the same partition
+	kafkaConfig.Producer.Partitioner = sarama.NewHashPartitioner
+	if p.config.Timeout > 0 {
+		kafkaConfig.Producer.Timeout = p.config.Timeout
+	}
+	producer, err := sarama.NewSyncProducer(p.brokers, kafkaConfig)
+	if err != nil {
+		logrus.Errorf("failed to create producer: %v", err)
+		return nil, err
+	}
+
+	p.producer = producer
+	return p.producer, nil
+}
+
+func (p *Producer) ProduceMessage(topic string, key string, data []byte) error {
+	producer, err := p.getProducer()
+	if err != nil {
+		return err
+	}
+
+	partition, offset, err := producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(data),
+	})
+	if err != nil {
+		logrus.Errorf("failed to produce message to kafka: %v", err)
+		return err
+	}
+
+	logrus.Infof("message is stored in topic(%s)/partition(%d)/offset(%d)", topic, partition, offset)
+	return nil
+}
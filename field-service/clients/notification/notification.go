@@ -0,0 +1,65 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"field-service/clients/config"
+	"field-service/common/utils"
+	config2 "field-service/config"
+	"field-service/constants"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type NotificationClient struct {
+	client config.IClientConfig
+}
+
+type INotificationClient interface {
+	Send(context.Context, *NotificationRequest) error
+}
+
+func NewNotificationClient(client config.IClientConfig) INotificationClient {
+	return &NotificationClient{client: client}
+}
+
+// Send the notification, only logged when no notification service is configured
+func (n *NotificationClient) Send(ctx context.Context, request *NotificationRequest) error {
+	if n.client.BaseURL() == "" {
+		logrus.Infof("notification %s to %s: %s", request.Type, request.Email, request.Message)
+		return nil
+	}
+
+	unixTime := time.Now().Unix()
+	generateAPIKey := fmt.Sprintf("%s:%s:%d",
+		config2.Config.AppName,
+		n.client.SignatureKey(),
+		unixTime,
+	)
+	apiKey := utils.GenerateSha256(generateAPIKey)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	var response NotificationResponse
+	res, _, errs := n.client.Client().Clone().
+		Post(fmt.Sprintf("%s/api/v1/notification", n.client.BaseURL())).
+		Set(constants.XApiKey, apiKey).
+		Set(constants.XServiceName, config2.Config.AppName).
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime)).
+		Send(string(body)).
+		EndStruct(&response)
+	if len(errs) > 0 {
+		return fmt.Errorf("request failed: %v", errs[0])
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status code: %d, message: %s", res.StatusCode, response.Message)
+	}
+	return nil
+}
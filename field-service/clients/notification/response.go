@@ -0,0 +1,23 @@
+package clients
+
+import "github.com/google/uuid"
+
+type NotificationType string
+
+const WaitlistOfferNotification NotificationType = "waitlist-offer"
+
+type NotificationRequest struct {
+	Type        NotificationType `json:"type"`
+	UserID      uuid.UUID        `json:"userID"`
+	Name        string           `json:"name"`
+	Email       string           `json:"email"`
+	PhoneNumber string           `json:"phoneNumber"`
+	Message     string           `json:"message"`
+	Data        map[string]any   `json:"data"`
+}
+
+type NotificationResponse struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
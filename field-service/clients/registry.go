@@ -2,6 +2,7 @@ package clients
 
 import (
 	"field-service/clients/config"
+	notificationClients "field-service/clients/notification"
 	clients "field-service/clients/user"
 	config2 "field-service/config"
 )
@@ -10,6 +11,7 @@ type ClientRegistry struct{}
 
 type IClientRegistry interface {
 	GetUser() clients.IUserClient
+	GetNotification() notificationClients.INotificationClient
 }
 
 func NewClientRegistry() IClientRegistry {
@@ -23,3 +25,11 @@ func (c *ClientRegistry) GetUser() clients.IUserClient {
 			config.WithSignatureKey(config2.Config.InternalService.User.SignatureKey),
 		))
 }
+
+func (c *ClientRegistry) GetNotification() notificationClients.INotificationClient {
+	return notificationClients.NewNotificationClient(
+		config.NewClientConfig(
+			config.WithBaseURL(config2.Config.InternalService.Notification.Host),
+			config.WithSignatureKey(config2.Config.InternalService.Notification.SignatureKey),
+		))
+}
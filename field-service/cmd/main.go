@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	commonKafka "common/kafka"
+	"context"
 	"encoding/base64"
 	"field-service/clients"
 	"field-service/common/gcs"
@@ -8,19 +10,24 @@ import (
 	"field-service/config"
 	"field-service/constants"
 	"field-service/controllers"
+	kafka "field-service/controllers/kafka"
+	kafkaConfig "field-service/controllers/kafka/config"
 	"field-service/domain/models"
 	"field-service/middlewares"
 	"field-service/repositories"
 	"field-service/routes"
 	"field-service/services"
+	"field-service/workers"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/IBM/sarama"
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -52,6 +59,8 @@ var command = &cobra.Command{
 			&models.Field{},
 			&models.FieldSchedule{},
 			&models.Time{},
+			&models.WaitlistEntry{},
+			&models.ProcessedEvent{},
 		)
 		if err != nil {
 			panic(err)
@@ -61,9 +70,17 @@ var command = &cobra.Command{
 		gcs := initGCS()
 		client := clients.NewClientRegistry()
 		repository := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repository, gcs)
+		producer := commonKafka.NewKafkaProducer(kafkaConfig.NewConfig(), config.Config.Kafka.Brokers)
+		service := services.NewServiceRegistry(repository, gcs, client, producer)
 		controller := controllers.NewControllerRegistry(service)
 
+		// Background worker for the waitlist offers
+		worker := workers.NewWorkerRegistry(service)
+		go worker.GetWaitlist().Start(context.Background())
+
+		// Book and release the field schedules from the order events
+		serveKafkaConsumer(service)
+
 		// Setup gin router
 		router := gin.Default()
 		router.Use(middlewares.HandlePanic())
@@ -115,6 +132,48 @@ func Run() {
 	}
 }
 
+func serveKafkaConsumer(service services.IServiceRegistry) {
+	kafkaConsumerConfig := sarama.NewConfig()
+	kafkaConsumerConfig.Consumer.MaxWaitTime = time.Duration(config.Config.Kafka.MaxWaitTimeInMs) * time.Millisecond
+	kafkaConsumerConfig.Consumer.MaxProcessingTime = time.Duration(config.Config.Kafka.MaxProcessingTimeInMs) * time.Millisecond
+	kafkaConsumerConfig.Consumer.Retry.Backoff = time.Duration(config.Config.Kafka.BackOffTimeInMs) * time.Millisecond
+	kafkaConsumerConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
+	kafkaConsumerConfig.Consumer.Offsets.AutoCommit.Enable = true
+	kafkaConsumerConfig.Consumer.Offsets.AutoCommit.Interval = 1 * time.Second
+	kafkaConsumerConfig.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{
+		sarama.NewBalanceStrategyRoundRobin(),
+	}
+
+	brokers := config.Config.Kafka.Brokers
+	groupID := config.Config.Kafka.GroupID
+	topics := config.Config.Kafka.Topics
+	consumerGroup, err := sarama.NewConsumerGroup(brokers, groupID, kafkaConsumerConfig)
+	if err != nil {
+		logrus.Errorf("failed to create consumer group: %v", err)
+		return
+	}
+
+	deadLetter := commonKafka.NewDeadLetter(kafkaConfig.NewConfig(), brokers, config.Config.Kafka.DeadLetterTopic)
+	consumer := commonKafka.NewConsumerGroup(kafkaConfig.NewConfig(), deadLetter)
+	kafkaRegistry := kafka.NewKafkaRegistry(service)
+	kafkaConsumer := kafkaConfig.NewKafkaConsumer(consumer, kafkaRegistry)
+	kafkaConsumer.Register()
+
+	// go routines for consumer, the group is consumed again after every rebalance
+	go func() {
+		defer consumerGroup.Close()
+		defer deadLetter.Close()
+		for {
+			err := consumerGroup.Consume(context.Background(), topics, consumer)
+			if err != nil {
+				logrus.Errorf("failed to consume: %v", err)
+				panic(err)
+			}
+		}
+	}()
+	logrus.Infof("kafka consumer started")
+}
+
 func initGCS() gcs.IGCSClient {
 	decode, err := base64.StdEncoding.DecodeString(config.Config.GCSPrivateKey)
 	if err != nil {
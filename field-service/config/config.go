@@ -11,26 +11,30 @@ import (
 var Config AppConfig
 
 type AppConfig struct {
-	Port                       int             `json:"port"`
-	AppName                    string          `json:"appName"`
-	AppEnv                     string          `json:"appEnv"`
-	SignatureKey               string          `json:"signatureKey"`
-	Database                   Database        `json:"database"`
-	RateLimiterMaxRequest      float64         `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond      int             `json:"rateLimiterTimeSecond"`
-	InternalService            InternalService `json:"internalService"`
-	GCSType                    string          `json:"gcsType"`
-	GCSProjectID               string          `json:"gcsProjectID"`
-	GCSPrivateKeyID            string          `json:"gcsPrivateKeyID"`
-	GCSPrivateKey              string          `json:"gcsPrivateKey"`
-	GCSClientEmail             string          `json:"gcsClientEmail"`
-	GCSClientID                string          `json:"gcsClientID"`
-	GCSAuthURI                 string          `json:"gcsAuthURI"`
-	GCSTokenURI                string          `json:"gcsTokenURI"`
-	GCSAuthProviderX509CertURL string          `json:"gcsAuthProviderX509CertURL"`
-	GCSClientX509CertURL       string          `json:"gcsClientX509CertURL"`
-	GCSUniverseDomain          string          `json:"gcsUniverseDomain"`
-	GCSBucketName              string          `json:"gcsBucketName"`
+	Port                       int               `json:"port"`
+	AppName                    string            `json:"appName"`
+	AppEnv                     string            `json:"appEnv"`
+	SignatureKey               string            `json:"signatureKey"`
+	Database                   Database          `json:"database"`
+	RateLimiterMaxRequest      float64           `json:"rateLimiterMaxRequest"`
+	RateLimiterTimeSecond      int               `json:"rateLimiterTimeSecond"`
+	InternalService            InternalService   `json:"internalService"`
+	GCSType                    string            `json:"gcsType"`
+	GCSProjectID               string            `json:"gcsProjectID"`
+	GCSPrivateKeyID            string            `json:"gcsPrivateKeyID"`
+	GCSPrivateKey              string            `json:"gcsPrivateKey"`
+	GCSClientEmail             string            `json:"gcsClientEmail"`
+	GCSClientID                string            `json:"gcsClientID"`
+	GCSAuthURI                 string            `json:"gcsAuthURI"`
+	GCSTokenURI                string            `json:"gcsTokenURI"`
+	GCSAuthProviderX509CertURL string            `json:"gcsAuthProviderX509CertURL"`
+	GCSClientX509CertURL       string            `json:"gcsClientX509CertURL"`
+	GCSUniverseDomain          string            `json:"gcsUniverseDomain"`
+	GCSBucketName              string            `json:"gcsBucketName"`
+	Waitlist                   Waitlist          `json:"waitlist"`
+	Kafka                      Kafka             `json:"kafka"`
+	InternalCallers            map[string]string `json:"internalCallers"`
+	HoldTTLInMinutes           int               `json:"holdTTLInMinutes"`
 }
 
 type Database struct {
@@ -46,7 +50,8 @@ type Database struct {
 }
 
 type InternalService struct {
-	User User `json:"user"`
+	User         User         `json:"user"`
+	Notification Notification `json:"notification"`
 }
 
 type User struct {
@@ -54,6 +59,30 @@ type User struct {
 	SignatureKey string `json:"signatureKey"`
 }
 
+type Notification struct {
+	Host         string `json:"host"`
+	SignatureKey string `json:"signatureKey"`
+}
+
+type Waitlist struct {
+	OfferTTLInMinutes             int `json:"offerTTLInMinutes"`
+	OfferSweeperIntervalInSeconds int `json:"offerSweeperIntervalInSeconds"`
+}
+
+type Kafka struct {
+	Brokers               []string `json:"brokers"`
+	TimeoutInMS           int      `json:"timeoutInMS"`
+	MaxRetry              int      `json:"maxRetry"`
+	Topics                []string `json:"topics"`
+	GroupID               string   `json:"groupID"`
+	MaxWaitTimeInMs       int      `json:"maxWaitTimeInMs"`
+	MaxProcessingTimeInMs int      `json:"maxProcessingTimeInMs"`
+	BackOffTimeInMs       int      `json:"backoffTimeInMs"`
+	MaxBackOffTimeInMs    int      `json:"maxBackoffTimeInMs"`
+	DeadLetterTopic       string   `json:"deadLetterTopic"`
+	EventTopic            string   `json:"eventTopic"`
+}
+
 func Init() {
 	err := utils.BindFromJSON(&Config, "config.json", ".")
 	if err != nil {
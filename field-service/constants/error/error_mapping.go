@@ -4,6 +4,7 @@ import (
 	errField "field-service/constants/error/field"
 	errFieldSchedule "field-service/constants/error/fieldschedule"
 	errTime "field-service/constants/error/time"
+	errWaitlist "field-service/constants/error/waitlist"
 )
 
 func ErrMapping(err error) bool {
@@ -12,6 +13,7 @@ func ErrMapping(err error) bool {
 		FieldErrors         = errField.FieldErrors
 		FieldScheduleErrors = errFieldSchedule.FieldScheduleErrors
 		TimeErrors          = errTime.TimeErrors
+		WaitlistErrors      = errWaitlist.WaitlistErrors
 	)
 
 	allErrors := make([]error, 0)
@@ -19,6 +21,7 @@ func ErrMapping(err error) bool {
 	allErrors = append(allErrors, FieldErrors...)
 	allErrors = append(allErrors, FieldScheduleErrors...)
 	allErrors = append(allErrors, TimeErrors...)
+	allErrors = append(allErrors, WaitlistErrors...)
 
 	for _, item := range allErrors {
 		if err.Error() == item.Error() {
@@ -3,11 +3,19 @@ package error
 import "errors"
 
 var (
-	ErrFieldScheduleNotFound = errors.New("field schedule not found")
-	ErrFieldScheduleIsExist  = errors.New("field schedule already exist")
+	ErrFieldScheduleNotFound      = errors.New("field schedule not found")
+	ErrFieldScheduleIsExist       = errors.New("field schedule already exist")
+	ErrInvalidFieldScheduleStatus = errors.New("invalid field schedule status")
+	ErrFieldScheduleNotAvailable  = errors.New("field schedule is not available")
+	ErrInvalidDateRange           = errors.New("invalid date range")
+	ErrDuplicateFieldSchedule     = errors.New("field schedule is requested more than once")
 )
 
 var FieldScheduleErrors = []error{
 	ErrFieldScheduleNotFound,
 	ErrFieldScheduleIsExist,
+	ErrInvalidFieldScheduleStatus,
+	ErrFieldScheduleNotAvailable,
+	ErrInvalidDateRange,
+	ErrDuplicateFieldSchedule,
 }
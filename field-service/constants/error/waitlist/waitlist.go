@@ -0,0 +1,15 @@
+package error
+
+import "errors"
+
+var (
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+	ErrAlreadyOnWaitlist     = errors.New("already on the waitlist of the field schedule")
+	ErrFieldScheduleIsOpen   = errors.New("field schedule is available, book it instead")
+)
+
+var WaitlistErrors = []error{
+	ErrWaitlistEntryNotFound,
+	ErrAlreadyOnWaitlist,
+	ErrFieldScheduleIsOpen,
+}
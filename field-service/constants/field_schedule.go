@@ -6,19 +6,23 @@ type FieldScheduleStatus int
 const (
 	Available FieldScheduleStatus = 100
 	Booked    FieldScheduleStatus = 200
+	Hold      FieldScheduleStatus = 300
 
 	AvailableString FieldScheduleStatusName = "Available"
 	BookedString    FieldScheduleStatusName = "Booked"
+	HoldString      FieldScheduleStatusName = "Hold"
 )
 
 var mapFieldScheduleStatusIntToString = map[FieldScheduleStatus]FieldScheduleStatusName{
 	Available: AvailableString,
 	Booked:    BookedString,
+	Hold:      HoldString,
 }
 
 var mapFileScheduleStatusStringToInt = map[FieldScheduleStatusName]FieldScheduleStatus{
 	AvailableString: Available,
 	BookedString:    Booked,
+	HoldString:      Hold,
 }
 
 func (f FieldScheduleStatus) GetStatusString() FieldScheduleStatusName {
@@ -28,3 +32,6 @@ func (f FieldScheduleStatus) GetStatusString() FieldScheduleStatusName {
 func (f FieldScheduleStatusName) GetStatusInt() FieldScheduleStatus {
 	return mapFileScheduleStatusStringToInt[f]
 }
+
+// Topic of the field schedule events when the config has none
+const FieldEventTopic = "field-service-event"
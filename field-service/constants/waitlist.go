@@ -0,0 +1,30 @@
+package constants
+
+type WaitlistStatus int
+type WaitlistStatusName string
+
+const (
+	Waiting          WaitlistStatus = 100
+	Offered          WaitlistStatus = 200
+	Claimed          WaitlistStatus = 300
+	OfferExpired     WaitlistStatus = 400
+	WaitlistCanceled WaitlistStatus = 500
+
+	WaitingString          WaitlistStatusName = "Waiting"
+	OfferedString          WaitlistStatusName = "Offered"
+	ClaimedString          WaitlistStatusName = "Claimed"
+	OfferExpiredString     WaitlistStatusName = "Expired"
+	WaitlistCanceledString WaitlistStatusName = "Canceled"
+)
+
+var mapWaitlistStatusIntToString = map[WaitlistStatus]WaitlistStatusName{
+	Waiting:          WaitingString,
+	Offered:          OfferedString,
+	Claimed:          ClaimedString,
+	OfferExpired:     OfferExpiredString,
+	WaitlistCanceled: WaitlistCanceledString,
+}
+
+func (w WaitlistStatus) GetStatusString() WaitlistStatusName {
+	return mapWaitlistStatusIntToString[w]
+}
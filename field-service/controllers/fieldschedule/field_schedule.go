@@ -16,14 +16,19 @@ type FieldScheduleController struct {
 }
 
 type IFieldScheduleController interface {
+	Lookup(*gin.Context)
 	GetAllWithPagination(*gin.Context)
 	GetAllByFieldIDAndDate(*gin.Context)
 	GetByUUID(*gin.Context)
 	Create(*gin.Context)
 	UpdateStatus(*gin.Context)
+	Hold(*gin.Context)
 	Update(*gin.Context)
 	Delete(*gin.Context)
 	GenerateScheduleForOneMonth(*gin.Context)
+	JoinWaitlist(*gin.Context)
+	LeaveWaitlist(*gin.Context)
+	GetWaitlistDepth(*gin.Context)
 }
 
 func NewScheduleController(service services.IServiceRegistry) IFieldScheduleController {
@@ -123,6 +128,51 @@ func (f *FieldScheduleController) GetAllByFieldIDAndDate(c *gin.Context) {
 
 }
 
+// Lookup Schedules Controller
+func (f *FieldScheduleController) Lookup(c *gin.Context) {
+	var params dto.FieldScheduleLookupRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPRes{
+			Code:    http.StatusUnprocessableEntity,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().Lookup(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
 // Get Schedule by UUID Controller
 func (f *FieldScheduleController) GetByUUID(c *gin.Context) {
 	result, err := f.service.GetFieldSchedule().GetByUUID(c, c.Param("uuid"))
@@ -279,6 +329,51 @@ func (f *FieldScheduleController) UpdateStatus(c *gin.Context) {
 
 }
 
+// Hold Schedule Controller
+func (f *FieldScheduleController) Hold(c *gin.Context) {
+	var request dto.HoldFieldScheduleRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPRes{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	err = f.service.GetFieldSchedule().Hold(c, &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusConflict,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusCreated,
+		Gin:  c,
+	})
+
+}
+
 // Delete Schedule Controller
 func (f *FieldScheduleController) Delete(c *gin.Context) {
 	err := f.service.GetFieldSchedule().Delete(c, c.Param("uuid"))
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Join Waitlist Controller
+func (f *FieldScheduleController) JoinWaitlist(c *gin.Context) {
+	result, err := f.service.GetFieldSchedule().JoinWaitlist(c.Request.Context(), c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// Leave Waitlist Controller
+func (f *FieldScheduleController) LeaveWaitlist(c *gin.Context) {
+	err := f.service.GetFieldSchedule().LeaveWaitlist(c.Request.Context(), c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+// Get Waitlist Depth Controller
+func (f *FieldScheduleController) GetWaitlistDepth(c *gin.Context) {
+	var params dto.WaitlistDepthRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPRes{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().GetWaitlistDepth(c.Request.Context(), &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
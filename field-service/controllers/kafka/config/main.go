@@ -0,0 +1,43 @@
+package kafka
+
+import (
+	commonKafka "common/kafka"
+	"field-service/config"
+	"field-service/controllers/kafka"
+	kafkaOrder "field-service/controllers/kafka/order"
+	"slices"
+	"time"
+)
+
+type Kafka struct {
+	consumer *commonKafka.ConsumerGroup
+	kafka    kafka.IKafkaRegistry
+}
+
+// Kafka settings of the service for the consumer group, the dead letter topic and the producer
+func NewConfig() commonKafka.Config {
+	return commonKafka.Config{
+		MaxRetry:   config.Config.Kafka.MaxRetry,
+		Timeout:    time.Duration(config.Config.Kafka.TimeoutInMS) * time.Millisecond,
+		BackOff:    time.Duration(config.Config.Kafka.BackOffTimeInMs) * time.Millisecond,
+		MaxBackOff: time.Duration(config.Config.Kafka.MaxBackOffTimeInMs) * time.Millisecond,
+	}
+}
+
+type IKafka interface {
+	Register()
+}
+
+func NewKafkaConsumer(consumer *commonKafka.ConsumerGroup, kafka kafka.IKafkaRegistry) IKafka {
+	return &Kafka{consumer: consumer, kafka: kafka}
+}
+
+func (k *Kafka) Register() {
+	k.orderHandler()
+}
+
+func (k *Kafka) orderHandler() {
+	if slices.Contains(config.Config.Kafka.Topics, kafkaOrder.OrderTopic) {
+		k.consumer.RegisterHandler(kafkaOrder.OrderTopic, k.kafka.GetOrder().HandleOrder)
+	}
+}
@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"common/event"
+	"context"
+	"encoding/json"
+	"field-service/domain/dto"
+	"field-service/services"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+const OrderTopic = "order-service-event"
+
+type OrderKafka struct {
+	service services.IServiceRegistry
+}
+
+type IOrderKafka interface {
+	HandleOrder(context.Context, *sarama.ConsumerMessage) error
+}
+
+func NewOrderKafka(service services.IServiceRegistry) IOrderKafka {
+	return &OrderKafka{service: service}
+}
+
+func (o *OrderKafka) HandleOrder(ctx context.Context, message *sarama.ConsumerMessage) error {
+	envelope, err := event.Decode(message.Value)
+	if err != nil {
+		logrus.Errorf("failed to decode order event: %v", err)
+		return err
+	}
+
+	if envelope.Version != event.OrderLatestVersion {
+		return event.ErrUnsupportedVersion
+	}
+
+	var payload dto.OrderEventV1
+	err = json.Unmarshal(envelope.Payload, &payload)
+	if err != nil {
+		logrus.Errorf("failed to unmarshal order event %s: %v", envelope.ID, err)
+		return err
+	}
+
+	err = o.service.GetFieldSchedule().HandleOrderEvent(ctx, envelope.ID, envelope.Type, &payload)
+	if err != nil {
+		logrus.Errorf("failed to handle order event %s: %v", envelope.ID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package kafka
+
+import (
+	kafka "field-service/controllers/kafka/order"
+	"field-service/services"
+)
+
+type Registry struct {
+	service services.IServiceRegistry
+}
+
+type IKafkaRegistry interface {
+	GetOrder() kafka.IOrderKafka
+}
+
+func NewKafkaRegistry(service services.IServiceRegistry) IKafkaRegistry {
+	return &Registry{service: service}
+}
+
+func (r *Registry) GetOrder() kafka.IOrderKafka {
+	return kafka.NewOrderKafka(r.service)
+}
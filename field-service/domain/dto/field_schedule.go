@@ -23,11 +23,20 @@ type UpdateFieldScheduleRequest struct {
 }
 
 type UpdateStatusFieldScheduleRequest struct {
-	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
+	FieldScheduleIDs []string                          `json:"fieldScheduleIDs" validate:"required"`
+	Status           constants.FieldScheduleStatusName `json:"status"`
+	OrderID          uuid.UUID                         `json:"orderID" validate:"required"`
+}
+
+type HoldFieldScheduleRequest struct {
+	FieldScheduleIDs []string   `json:"fieldScheduleIDs" validate:"required"`
+	UserID           *uuid.UUID `json:"userID"`
+	OrderID          uuid.UUID  `json:"orderID" validate:"required"`
 }
 
 type FieldScheduleResponse struct {
 	UUID         uuid.UUID                         `json:"uuid"`
+	FieldID      uuid.UUID                         `json:"fieldID"`
 	FieldName    string                            `json:"fieldName"`
 	PricePerHour int                               `json:"pricePerHour"`
 	Date         string                            `json:"date"`
@@ -52,6 +61,14 @@ type FieldScheduleRequestParam struct {
 	SortOrder  *string `form:"sortOrder"`
 }
 
+type FieldScheduleLookupRequestParam struct {
+	FieldID   string `form:"fieldID" validate:"required,uuid"`
+	TimeID    string `form:"timeID" validate:"required,uuid"`
+	Weekday   *int   `form:"weekday" validate:"required,min=0,max=6"`
+	StartDate string `form:"startDate" validate:"required"`
+	EndDate   string `form:"endDate" validate:"required"`
+}
+
 type FieldScheduleByFieldIDAndDateRequestParam struct {
 	Date string `form:"date" validate:"required"`
 }
@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/google/uuid"
+
+// Payload of field.schedule.booked version 1
+type FieldScheduleBookedEventV1 struct {
+	OrderID          uuid.UUID   `json:"orderID"`
+	FieldScheduleIDs []uuid.UUID `json:"fieldScheduleIDs"`
+}
+
+// Payload of field.schedule.booking-failed version 1
+type FieldScheduleBookingFailedEventV1 struct {
+	OrderID          uuid.UUID   `json:"orderID"`
+	FieldScheduleIDs []uuid.UUID `json:"fieldScheduleIDs"`
+	Reason           string      `json:"reason"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/google/uuid"
+
+// Payload of the order events published by order-service, version 1
+type OrderEventV1 struct {
+	OrderID          uuid.UUID   `json:"orderID"`
+	Code             string      `json:"code"`
+	UserID           uuid.UUID   `json:"userID"`
+	Amount           float64     `json:"amount"`
+	Status           string      `json:"status"`
+	FieldScheduleIDs []uuid.UUID `json:"fieldScheduleIDs"`
+}
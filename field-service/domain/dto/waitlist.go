@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WaitlistEntryResponse struct {
+	UUID            uuid.UUID                    `json:"uuid"`
+	FieldScheduleID uuid.UUID                    `json:"fieldScheduleID"`
+	Status          constants.WaitlistStatusName `json:"status"`
+	Position        int64                        `json:"position,omitempty"`
+	OfferExpiredAt  *time.Time                   `json:"offerExpiredAt,omitempty"`
+	CreatedAt       *time.Time                   `json:"createdAt"`
+}
+
+type WaitlistDepthRequestParam struct {
+	FieldID *string `form:"fieldID" validate:"omitempty,uuid"`
+	Date    *string `form:"date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// Number of customers waiting for a schedule
+type WaitlistScheduleDepth struct {
+	FieldScheduleID uuid.UUID
+	FieldID         uuid.UUID
+	FieldName       string
+	Date            time.Time
+	StartTime       string
+	EndTime         string
+	Depth           int64
+}
+
+type WaitlistDepthResponse struct {
+	FieldID   uuid.UUID                       `json:"fieldID"`
+	FieldName string                          `json:"fieldName"`
+	Date      string                          `json:"date"`
+	Depth     int64                           `json:"depth"`
+	Schedules []WaitlistScheduleDepthResponse `json:"schedules"`
+}
+
+type WaitlistScheduleDepthResponse struct {
+	UUID  uuid.UUID `json:"uuid"`
+	Time  string    `json:"time"`
+	Depth int64     `json:"depth"`
+}
@@ -8,15 +8,18 @@ import (
 )
 
 type FieldSchedule struct {
-	ID        uint                          `gorm:"primaryKey;autoIncrement"`
-	UUID      uuid.UUID                     `gorm:"type:uuid;not null"`
-	FieldID   uint                          `gorm:"type:int;not null"`
-	TimeID    uint                          `gorm:"type:int;not null"`
-	Date      time.Time                     `gorm:"type:date;not null"`
-	Status    constants.FieldScheduleStatus `gorm:"type:int;not null"`
-	CreatedAt *time.Time
-	UpdatedAt *time.Time
-	DeletedAt *time.Time
-	Field     Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Time      Time  `gorm:"foreignKey:time_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID            uint                          `gorm:"primaryKey;autoIncrement"`
+	UUID          uuid.UUID                     `gorm:"type:uuid;not null"`
+	FieldID       uint                          `gorm:"type:int;not null"`
+	TimeID        uint                          `gorm:"type:int;not null"`
+	Date          time.Time                     `gorm:"type:date;not null"`
+	Status        constants.FieldScheduleStatus `gorm:"type:int;not null"`
+	HoldExpiredAt *time.Time                    `gorm:"type:timestamp"`
+	HoldUserID    *uuid.UUID                    `gorm:"type:uuid"`
+	OrderID       *uuid.UUID                    `gorm:"type:uuid;index"`
+	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
+	DeletedAt     *time.Time
+	Field         Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Time          Time  `gorm:"foreignKey:time_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Customer waiting for a booked field schedule, offered an exclusive hold when it is released
+type WaitlistEntry struct {
+	ID              uint                     `gorm:"primaryKey;autoIncrement"`
+	UUID            uuid.UUID                `gorm:"type:uuid;not null"`
+	FieldScheduleID uint                     `gorm:"type:int;not null;index"`
+	UserID          uuid.UUID                `gorm:"type:uuid;not null"`
+	Name            string                   `gorm:"type:varchar(100);not null"`
+	Email           string                   `gorm:"type:varchar(100);not null"`
+	PhoneNumber     string                   `gorm:"type:varchar(20)"`
+	Status          constants.WaitlistStatus `gorm:"type:int;not null"`
+	OfferExpiredAt  *time.Time               `gorm:"type:timestamp"`
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+	FieldSchedule   FieldSchedule `gorm:"foreignKey:field_schedule_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	clients "field-service/clients"
@@ -51,7 +52,7 @@ func RateLimiter(lmt *limiter.Limiter) gin.HandlerFunc {
 }
 
 func extractBearerToken(token string) string {
-	arrayToken := strings.Split(token, "")
+	arrayToken := strings.Split(token, " ")
 	if len(arrayToken) == 2 {
 		return arrayToken[1]
 	}
@@ -66,11 +67,10 @@ func responseUnauthorized(c *gin.Context, message string) {
 	c.Abort()
 }
 
-func validateAPIKey(c *gin.Context) error {
+func validateAPIKey(c *gin.Context, signatureKey string) error {
 	apiKey := c.GetHeader(constants.XApiKey)
 	requestAt := c.GetHeader(constants.XRequestAt)
 	serviceName := c.GetHeader(constants.XServiceName)
-	signatureKey := config.Config.SignatureKey
 
 	validateKey := fmt.Sprintf("%s:%s:%s", serviceName, signatureKey, requestAt)
 	hash := sha256.New()
@@ -106,6 +106,8 @@ func CheckRole(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
 			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
 			return
 		}
+		userLogin := c.Request.WithContext(context.WithValue(c.Request.Context(), constants.User, user))
+		c.Request = userLogin
 		c.Next()
 	}
 }
@@ -114,16 +116,21 @@ func Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var err error
 		token := c.GetHeader(constants.Authorization)
-		if token != "" {
+		if token == "" {
 			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
 			return
 		}
 
-		err = validateAPIKey(c)
+		err = validateAPIKey(c, config.Config.SignatureKey)
 		if err != nil {
 			responseUnauthorized(c, err.Error())
 			return
 		}
+
+		tokenString := extractBearerToken(token)
+		tokenUser := c.Request.WithContext(context.WithValue(c.Request.Context(), constants.Token, tokenString))
+		c.Request = tokenUser
+		c.Next()
 	}
 
 }
@@ -131,7 +138,7 @@ func Authenticate() gin.HandlerFunc {
 // Login without token
 func AuthenticateWithoutToken() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		err := validateAPIKey(c)
+		err := validateAPIKey(c, config.Config.SignatureKey)
 		if err != nil {
 			responseUnauthorized(c, err.Error())
 			return
@@ -139,3 +146,21 @@ func AuthenticateWithoutToken() gin.HandlerFunc {
 	}
 
 }
+
+// Internal services on the allowlist only, each one signs with its own key
+func AuthenticateInternal() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		signatureKey, ok := config.Config.InternalCallers[c.GetHeader(constants.XServiceName)]
+		if !ok || signatureKey == "" {
+			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		err := validateAPIKey(c, signatureKey)
+		if err != nil {
+			responseUnauthorized(c, err.Error())
+			return
+		}
+		c.Next()
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -21,11 +23,16 @@ type FieldScheduleRepository struct {
 type IFieldScheduleRepository interface {
 	FindAllWithPagination(context.Context, *dto.FieldScheduleRequestParam) ([]models.FieldSchedule, int64, error)
 	FindAllByFieldIDAndDate(context.Context, int, string) ([]models.FieldSchedule, error)
+	FindAllByFieldIDAndTimeID(context.Context, int, int, string, string) ([]models.FieldSchedule, error)
 	FindByUUID(context.Context, string) (*models.FieldSchedule, error)
 	FindByDateAndTimeID(context.Context, string, int, int) (*models.FieldSchedule, error)
 	Create(context.Context, []models.FieldSchedule) error
 	Update(context.Context, string, *models.FieldSchedule) (*models.FieldSchedule, error)
 	UpdateStatus(context.Context, constants.FieldScheduleStatus, string) error
+	Hold(context.Context, []string, time.Time, *uuid.UUID, uuid.UUID) error
+	Book(context.Context, []string, uuid.UUID) error
+	Release(context.Context, string, uuid.UUID) (bool, error)
+	Offer(context.Context, uint, uuid.UUID, time.Time) error
 	Delete(context.Context, string) error
 }
 
@@ -97,6 +104,30 @@ func (f *FieldScheduleRepository) FindAllByFieldIDAndDate(
 	return fieldSchedules, nil
 }
 
+func (f *FieldScheduleRepository) FindAllByFieldIDAndTimeID(
+	ctx context.Context,
+	fieldID int,
+	timeID int,
+	startDate string,
+	endDate string,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := f.db.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time").
+		Where("field_id = ?", fieldID).
+		Where("time_id = ?", timeID).
+		Where("date BETWEEN ? AND ?", startDate, endDate).
+		Order("date asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return fieldSchedules, nil
+}
+
 func (f *FieldScheduleRepository) FindByUUID(ctx context.Context, uuid string) (*models.FieldSchedule, error) {
 	var fieldSchedule models.FieldSchedule
 	err := f.db.
@@ -174,6 +205,9 @@ func (f *FieldScheduleRepository) UpdateStatus(
 	}
 
 	fieldSchedule.Status = status
+	fieldSchedule.HoldExpiredAt = nil
+	fieldSchedule.HoldUserID = nil
+	fieldSchedule.OrderID = nil
 	err = f.db.WithContext(ctx).Save(&fieldSchedule).Error
 	if err != nil {
 		return errWrap.WrapError(errConstant.ErrSQLError)
@@ -181,6 +215,130 @@ func (f *FieldScheduleRepository) UpdateStatus(
 	return nil
 }
 
+// Every schedule is counted once by the conditional updates, so a repeated one would read as taken
+func hasDuplicate(uuids []string) bool {
+	seen := make(map[string]struct{}, len(uuids))
+	for _, item := range uuids {
+		if _, ok := seen[item]; ok {
+			return true
+		}
+		seen[item] = struct{}{}
+	}
+	return false
+}
+
+// Hold all of the schedules for the order at once, or none of them. A schedule offered to the user from the waitlist can be held by them,
+// and holding again for the same order succeeds.
+func (f *FieldScheduleRepository) Hold(
+	ctx context.Context,
+	uuids []string,
+	expiredAt time.Time,
+	userID *uuid.UUID,
+	orderID uuid.UUID,
+) error {
+	if hasDuplicate(uuids) {
+		return errWrap.WrapError(errFieldSchedule.ErrDuplicateFieldSchedule)
+	}
+
+	return f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.
+			Model(&models.FieldSchedule{}).
+			Where("uuid IN ?", uuids)
+		if userID != nil {
+			query = query.Where("status = ? OR (status = ? AND (hold_expired_at < ? OR hold_user_id = ? OR order_id = ?))",
+				constants.Available, constants.Hold, time.Now(), *userID, orderID)
+		} else {
+			query = query.Where("status = ? OR (status = ? AND (hold_expired_at < ? OR order_id = ?))",
+				constants.Available, constants.Hold, time.Now(), orderID)
+		}
+
+		result := query.Updates(map[string]any{
+			"status":          constants.Hold,
+			"hold_expired_at": expiredAt,
+			"hold_user_id":    nil,
+			"order_id":        orderID,
+		})
+		if result.Error != nil {
+			return errWrap.WrapError(errConstant.ErrSQLError)
+		}
+
+		// Some of the schedules are already booked or held by another order
+		if result.RowsAffected != int64(len(uuids)) {
+			return errWrap.WrapError(errFieldSchedule.ErrFieldScheduleNotAvailable)
+		}
+		return nil
+	})
+}
+
+// Book all of the schedules for the paid order at once, or none of them. Only the order holding a schedule can book it
+// while the hold lasts, and booking again for the same order succeeds.
+func (f *FieldScheduleRepository) Book(ctx context.Context, uuids []string, orderID uuid.UUID) error {
+	if hasDuplicate(uuids) {
+		return errWrap.WrapError(errFieldSchedule.ErrDuplicateFieldSchedule)
+	}
+
+	return f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&models.FieldSchedule{}).
+			Where("uuid IN ?", uuids).
+			Where("status = ? OR (status IN ? AND order_id = ?) OR (status = ? AND hold_expired_at < ?)",
+				constants.Available, []constants.FieldScheduleStatus{constants.Hold, constants.Booked}, orderID,
+				constants.Hold, time.Now()).
+			Updates(map[string]any{
+				"status":          constants.Booked,
+				"hold_expired_at": nil,
+				"hold_user_id":    nil,
+				"order_id":        orderID,
+			})
+		if result.Error != nil {
+			return errWrap.WrapError(errConstant.ErrSQLError)
+		}
+
+		// Some of the schedules are booked by another order or offered to another customer
+		if result.RowsAffected != int64(len(uuids)) {
+			return errWrap.WrapError(errFieldSchedule.ErrFieldScheduleNotAvailable)
+		}
+		return nil
+	})
+}
+
+// Release the schedule held or booked by the order, a schedule which now belongs to another order is left as it is
+func (f *FieldScheduleRepository) Release(ctx context.Context, uuid string, orderID uuid.UUID) (bool, error) {
+	result := f.db.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("uuid = ? AND order_id = ? AND status IN ?",
+			uuid, orderID, []constants.FieldScheduleStatus{constants.Hold, constants.Booked}).
+		Updates(map[string]any{
+			"status":          constants.Available,
+			"hold_expired_at": nil,
+			"hold_user_id":    nil,
+			"order_id":        nil,
+		})
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Hold the schedule exclusively for the customer offered from the waitlist
+func (f *FieldScheduleRepository) Offer(ctx context.Context, id uint, userID uuid.UUID, expiredAt time.Time) error {
+	err := f.db.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":          constants.Hold,
+			"hold_expired_at": expiredAt,
+			"hold_user_id":    userID,
+			"order_id":        nil,
+		}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
 func (f *FieldScheduleRepository) Delete(ctx context.Context, uuid string) error {
 	err := f.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.FieldSchedule{}).Error
 	if err != nil {
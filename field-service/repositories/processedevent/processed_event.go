@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	"field-service/domain/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProcessedEventRepository struct {
+	db *gorm.DB
+}
+
+type IProcessedEventRepository interface {
+	Exists(context.Context, string) (bool, error)
+	Create(context.Context, string, string) error
+}
+
+func NewProcessedEventRepository(db *gorm.DB) IProcessedEventRepository {
+	return &ProcessedEventRepository{db: db}
+}
+
+func (p *ProcessedEventRepository) Exists(ctx context.Context, eventID string) (bool, error) {
+	var total int64
+	err := p.db.WithContext(ctx).Model(&models.ProcessedEvent{}).Where("event_id = ?", eventID).Count(&total).Error
+	if err != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return total > 0, nil
+}
+
+func (p *ProcessedEventRepository) Create(ctx context.Context, eventID, eventName string) error {
+	err := p.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ProcessedEvent{
+		EventID:     eventID,
+		EventName:   eventName,
+		ProcessedAt: time.Now(),
+	}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package repositories
 import (
 	fieldRepo "field-service/repositories/field"
 	fieldSchedule "field-service/repositories/fieldschedule"
+	processedEventRepo "field-service/repositories/processedevent"
 	timeRepo "field-service/repositories/time"
+	waitlistRepo "field-service/repositories/waitlist"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +18,8 @@ type IRepositoryRegistry interface {
 	GetField() fieldRepo.IFieldRepository
 	GetFieldSchedule() fieldSchedule.IFieldScheduleRepository
 	GetTime() timeRepo.ITimeRepository
+	GetWaitlist() waitlistRepo.IWaitlistRepository
+	GetProcessedEvent() processedEventRepo.IProcessedEventRepository
 }
 
 func NewRepositoryRegistry(db *gorm.DB) IRepositoryRegistry {
@@ -33,3 +37,11 @@ func (r *Registry) GetFieldSchedule() fieldSchedule.IFieldScheduleRepository {
 func (r *Registry) GetTime() timeRepo.ITimeRepository {
 	return timeRepo.NewTimeRepository(r.db)
 }
+
+func (r *Registry) GetWaitlist() waitlistRepo.IWaitlistRepository {
+	return waitlistRepo.NewWaitlistRepository(r.db)
+}
+
+func (r *Registry) GetProcessedEvent() processedEventRepo.IProcessedEventRepository {
+	return processedEventRepo.NewProcessedEventRepository(r.db)
+}
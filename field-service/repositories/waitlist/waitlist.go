@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	"field-service/constants"
+	errConstant "field-service/constants/error"
+	errWaitlist "field-service/constants/error/waitlist"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WaitlistRepository struct {
+	db *gorm.DB
+}
+
+type IWaitlistRepository interface {
+	FindActiveByUserID(context.Context, uint, uuid.UUID) (*models.WaitlistEntry, error)
+	FindFirstWaiting(context.Context, uint) (*models.WaitlistEntry, error)
+	FindAllExpiredOffers(context.Context, time.Time) ([]models.WaitlistEntry, error)
+	CountAhead(context.Context, *models.WaitlistEntry) (int64, error)
+	CountDepth(context.Context, *dto.WaitlistDepthRequestParam) ([]dto.WaitlistScheduleDepth, error)
+	Create(context.Context, *models.WaitlistEntry) (*models.WaitlistEntry, error)
+	Update(context.Context, *models.WaitlistEntry) error
+	Claim(context.Context, []string, uuid.UUID) error
+}
+
+func NewWaitlistRepository(db *gorm.DB) IWaitlistRepository {
+	return &WaitlistRepository{db: db}
+}
+
+// Waiting or offered entry of the user on the schedule
+func (w *WaitlistRepository) FindActiveByUserID(
+	ctx context.Context,
+	fieldScheduleID uint,
+	userID uuid.UUID,
+) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := w.db.
+		WithContext(ctx).
+		Where("field_schedule_id = ?", fieldScheduleID).
+		Where("user_id = ?", userID).
+		Where("status IN ?", []constants.WaitlistStatus{constants.Waiting, constants.Offered}).
+		First(&entry).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errWaitlist.ErrWaitlistEntryNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &entry, nil
+}
+
+// First customer in line, nil when nobody is waiting
+func (w *WaitlistRepository) FindFirstWaiting(ctx context.Context, fieldScheduleID uint) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := w.db.
+		WithContext(ctx).
+		Where("field_schedule_id = ?", fieldScheduleID).
+		Where("status = ?", constants.Waiting).
+		Order("id asc").
+		First(&entry).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &entry, nil
+}
+
+func (w *WaitlistRepository) FindAllExpiredOffers(ctx context.Context, now time.Time) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := w.db.
+		WithContext(ctx).
+		Preload("FieldSchedule").
+		Preload("FieldSchedule.Field").
+		Preload("FieldSchedule.Time").
+		Where("status = ?", constants.Offered).
+		Where("offer_expired_at < ?", now).
+		Order("id asc").
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return entries, nil
+}
+
+// Number of customers waiting in front of the entry
+func (w *WaitlistRepository) CountAhead(ctx context.Context, entry *models.WaitlistEntry) (int64, error) {
+	var total int64
+	err := w.db.
+		WithContext(ctx).
+		Model(&models.WaitlistEntry{}).
+		Where("field_schedule_id = ?", entry.FieldScheduleID).
+		Where("status = ?", constants.Waiting).
+		Where("id < ?", entry.ID).
+		Count(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return total, nil
+}
+
+func (w *WaitlistRepository) CountDepth(
+	ctx context.Context,
+	param *dto.WaitlistDepthRequestParam,
+) ([]dto.WaitlistScheduleDepth, error) {
+	var depths []dto.WaitlistScheduleDepth
+	query := w.db.
+		WithContext(ctx).
+		Model(&models.WaitlistEntry{}).
+		Select("field_schedules.uuid AS field_schedule_id, fields.uuid AS field_id, fields.name AS field_name, "+
+			"field_schedules.date, times.start_time, times.end_time, COUNT(waitlist_entries.id) AS depth").
+		Joins("JOIN field_schedules ON field_schedules.id = waitlist_entries.field_schedule_id").
+		Joins("JOIN fields ON fields.id = field_schedules.field_id").
+		Joins("JOIN times ON times.id = field_schedules.time_id").
+		Where("waitlist_entries.status = ?", constants.Waiting)
+
+	if param.FieldID != nil {
+		query = query.Where("fields.uuid = ?", *param.FieldID)
+	}
+	if param.Date != nil {
+		query = query.Where("field_schedules.date = ?", *param.Date)
+	} else {
+		query = query.Where("field_schedules.date >= ?", time.Now().Format(time.DateOnly))
+	}
+
+	err := query.
+		Group("field_schedules.uuid, fields.uuid, fields.name, field_schedules.date, times.start_time, times.end_time").
+		Order("field_schedules.date asc, fields.name asc, times.start_time asc").
+		Scan(&depths).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return depths, nil
+}
+
+func (w *WaitlistRepository) Create(ctx context.Context, req *models.WaitlistEntry) (*models.WaitlistEntry, error) {
+	entry := &models.WaitlistEntry{
+		UUID:            uuid.New(),
+		FieldScheduleID: req.FieldScheduleID,
+		UserID:          req.UserID,
+		Name:            req.Name,
+		Email:           req.Email,
+		PhoneNumber:     req.PhoneNumber,
+		Status:          constants.Waiting,
+	}
+
+	err := w.db.WithContext(ctx).Create(entry).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return entry, nil
+}
+
+func (w *WaitlistRepository) Update(ctx context.Context, req *models.WaitlistEntry) error {
+	err := w.db.
+		WithContext(ctx).
+		Model(&models.WaitlistEntry{}).
+		Where("id = ?", req.ID).
+		Select("status", "offer_expired_at").
+		Updates(req).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+// Mark the offers of the user on the schedules as claimed
+func (w *WaitlistRepository) Claim(ctx context.Context, fieldScheduleUUIDs []string, userID uuid.UUID) error {
+	err := w.db.
+		WithContext(ctx).
+		Model(&models.WaitlistEntry{}).
+		Where("field_schedule_id IN (?)", w.db.Model(&models.FieldSchedule{}).Select("id").Where("uuid IN ?", fieldScheduleUUIDs)).
+		Where("user_id = ?", userID).
+		Where("status = ?", constants.Offered).
+		Update("status", constants.Claimed).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -34,15 +34,26 @@ func (f *FieldScheduleRoute) Run() {
 	// Without login routes :
 	group.GET("", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().GetAllByFieldIDAndDate)
 
+	group.GET("/lookup", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().Lookup)
+
 	group.GET("/:uuid", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().GetByUUID)
 
-	group.PATCH("", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().UpdateStatus)
+	// Internal service routes :
+	group.POST("/hold", middlewares.AuthenticateInternal(), f.controller.GetFieldSchedule().Hold)
+
+	group.PATCH("", middlewares.AuthenticateInternal(), f.controller.GetFieldSchedule().UpdateStatus)
 
 	// Must login routes :
 	group.Use(middlewares.Authenticate())
 
 	group.GET("/pagination", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, f.client), f.controller.GetFieldSchedule().GetAllWithPagination)
 
+	group.GET("/waitlist", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldSchedule().GetWaitlistDepth)
+
+	group.POST("/:uuid/waitlist", middlewares.CheckRole([]string{constants.Customer}, f.client), f.controller.GetFieldSchedule().JoinWaitlist)
+
+	group.DELETE("/:uuid/waitlist", middlewares.CheckRole([]string{constants.Customer}, f.client), f.controller.GetFieldSchedule().LeaveWaitlist)
+
 	group.POST("", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldSchedule().Create)
 
 	group.POST("/one-month", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldSchedule().GenerateScheduleForOneMonth)
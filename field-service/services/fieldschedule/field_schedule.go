@@ -1,8 +1,11 @@
 package services
 
 import (
+	"common/kafka"
 	"context"
+	"field-service/clients"
 	"field-service/common/utils"
+	"field-service/config"
 	"field-service/constants"
 	errFieldSchedule "field-service/constants/error/fieldschedule"
 	"field-service/domain/dto"
@@ -12,25 +15,49 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 type FieldScheduleService struct {
 	repository repositories.IRepositoryRegistry
+	client     clients.IClientRegistry
+	producer   kafka.IProducer
 }
 
 type IFieldScheduleService interface {
 	GetAllWithPagination(context.Context, *dto.FieldScheduleRequestParam) (*utils.PaginationResult, error)
 	GetAllByFieldIDAndDate(context.Context, string, string) ([]dto.FieldScheduleForBookingResponse, error)
 	GetByUUID(context.Context, string) (*dto.FieldScheduleResponse, error)
+	Lookup(context.Context, *dto.FieldScheduleLookupRequestParam) ([]dto.FieldScheduleResponse, error)
 	GenerateScheduleForOneMonth(context.Context, *dto.GenerateFieldScheduleForOneMonthRequest) error
 	Create(context.Context, *dto.FieldScheduleRequest) error
 	Update(context.Context, string, *dto.UpdateFieldScheduleRequest) (*dto.FieldScheduleResponse, error)
 	UpdateStatus(context.Context, *dto.UpdateStatusFieldScheduleRequest) error
+	Hold(context.Context, *dto.HoldFieldScheduleRequest) error
 	Delete(context.Context, string) error
+	JoinWaitlist(context.Context, string) (*dto.WaitlistEntryResponse, error)
+	LeaveWaitlist(context.Context, string) error
+	GetWaitlistDepth(context.Context, *dto.WaitlistDepthRequestParam) ([]dto.WaitlistDepthResponse, error)
+	ExpireWaitlistOffers(context.Context) error
+	HandleOrderEvent(context.Context, string, string, *dto.OrderEventV1) error
 }
 
-func NewFieldScheduleService(repository repositories.IRepositoryRegistry) IFieldScheduleService {
-	return &FieldScheduleService{repository: repository}
+func NewFieldScheduleService(
+	repository repositories.IRepositoryRegistry,
+	client clients.IClientRegistry,
+	producer kafka.IProducer,
+) IFieldScheduleService {
+	return &FieldScheduleService{repository: repository, client: client, producer: producer}
+}
+
+// Hold which has passed its expiry is available again
+func (f *FieldScheduleService) currentStatus(fieldSchedule *models.FieldSchedule) constants.FieldScheduleStatusName {
+	if fieldSchedule.Status == constants.Hold &&
+		fieldSchedule.HoldExpiredAt != nil &&
+		fieldSchedule.HoldExpiredAt.Before(time.Now()) {
+		return constants.AvailableString
+	}
+	return fieldSchedule.Status.GetStatusString()
 }
 
 // Get All Field with Pagination
@@ -47,10 +74,11 @@ func (f *FieldScheduleService) GetAllWithPagination(
 	for _, schedule := range fieldSchedules {
 		fieldScheduleResults = append(fieldScheduleResults, dto.FieldScheduleResponse{
 			UUID:         schedule.UUID,
+			FieldID:      schedule.Field.UUID,
 			FieldName:    schedule.Field.Name,
 			Date:         schedule.Date.Format("2006-01-02"),
 			PricePerHour: schedule.Field.PricePerHour,
-			Status:       schedule.Status.GetStatusString(),
+			Status:       f.currentStatus(&schedule),
 			Time:         fmt.Sprintf("%s - %s", schedule.Time.StartTime, schedule.Time.EndTime),
 			CreatedAt:    schedule.CreatedAt,
 			UpdatedAt:    schedule.UpdatedAt,
@@ -120,7 +148,7 @@ func (f *FieldScheduleService) GetAllByFieldIDAndDate(
 			UUID:         fieldSchedule.UUID,
 			PricePerHour: utils.RupiahFormat(&pricePerHour),
 			Date:         f.convertMonthName(fieldSchedule.Date.Format("2006-01-02")),
-			Status:       fieldSchedule.Status.GetStatusString(),
+			Status:       f.currentStatus(&fieldSchedule),
 			Time:         fmt.Sprintf("%s - %s", startTime.Format("15:04"), endTime.Format("15:04")),
 		})
 	}
@@ -137,10 +165,11 @@ func (f *FieldScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto
 
 	response := dto.FieldScheduleResponse{
 		UUID:         fieldSchedule.UUID,
+		FieldID:      fieldSchedule.Field.UUID,
 		FieldName:    fieldSchedule.Field.Name,
 		PricePerHour: fieldSchedule.Field.PricePerHour,
 		Date:         fieldSchedule.Date.Format(time.DateOnly),
-		Status:       fieldSchedule.Status.GetStatusString(),
+		Status:       f.currentStatus(fieldSchedule),
 		Time:         fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
 		CreatedAt:    fieldSchedule.CreatedAt,
 		UpdatedAt:    fieldSchedule.UpdatedAt,
@@ -148,6 +177,64 @@ func (f *FieldScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto
 	return &response, nil
 }
 
+// Lookup the schedules of a field on a weekday and time slot between two dates (used by recurring orders)
+func (f *FieldScheduleService) Lookup(
+	ctx context.Context,
+	param *dto.FieldScheduleLookupRequestParam,
+) ([]dto.FieldScheduleResponse, error) {
+	startDate, err := time.Parse(time.DateOnly, param.StartDate)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	endDate, err := time.Parse(time.DateOnly, param.EndDate)
+	if err != nil || endDate.Before(startDate) {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	field, err := f.repository.GetField().FindByUUID(ctx, param.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleTime, err := f.repository.GetTime().FindByUUID(ctx, param.TimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedules, err := f.repository.GetFieldSchedule().FindAllByFieldIDAndTimeID(
+		ctx,
+		int(field.ID),
+		int(scheduleTime.ID),
+		param.StartDate,
+		param.EndDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	weekday := time.Weekday(*param.Weekday)
+	fieldScheduleResults := make([]dto.FieldScheduleResponse, 0, len(fieldSchedules))
+	for _, schedule := range fieldSchedules {
+		if schedule.Date.Weekday() != weekday {
+			continue
+		}
+
+		fieldScheduleResults = append(fieldScheduleResults, dto.FieldScheduleResponse{
+			UUID:         schedule.UUID,
+			FieldID:      schedule.Field.UUID,
+			FieldName:    schedule.Field.Name,
+			Date:         schedule.Date.Format(time.DateOnly),
+			PricePerHour: schedule.Field.PricePerHour,
+			Status:       f.currentStatus(&schedule),
+			Time:         fmt.Sprintf("%s - %s", schedule.Time.StartTime, schedule.Time.EndTime),
+			CreatedAt:    schedule.CreatedAt,
+			UpdatedAt:    schedule.UpdatedAt,
+		})
+	}
+	return fieldScheduleResults, nil
+}
+
 // Create Field Data for one month
 func (f *FieldScheduleService) GenerateScheduleForOneMonth(
 	ctx context.Context,
@@ -295,10 +382,11 @@ func (f *FieldScheduleService) Update(
 
 	response := dto.FieldScheduleResponse{
 		UUID:         fieldResult.UUID,
+		FieldID:      fieldResult.Field.UUID,
 		FieldName:    fieldResult.Field.Name,
 		Date:         fieldResult.Date.Format(time.DateOnly),
 		PricePerHour: fieldResult.Field.PricePerHour,
-		Status:       fieldSchedule.Status.GetStatusString(),
+		Status:       f.currentStatus(fieldSchedule),
 		Time:         fmt.Sprintf("%s - %s", scheduleTime.StartTime, scheduleTime.EndTime),
 		CreatedAt:    fieldResult.CreatedAt,
 		UpdatedAt:    fieldResult.UpdatedAt,
@@ -311,13 +399,31 @@ func (f *FieldScheduleService) UpdateStatus(
 	ctx context.Context,
 	request *dto.UpdateStatusFieldScheduleRequest,
 ) error {
+	// Booked is the default status for backward compatibility
+	status := constants.Booked
+	if request.Status != "" {
+		status = request.Status.GetStatusInt()
+		if status == 0 {
+			return errFieldSchedule.ErrInvalidFieldScheduleStatus
+		}
+	}
+
+	// Only the order holding the schedules can book or release them
+	switch status {
+	case constants.Booked:
+		return f.repository.GetFieldSchedule().Book(ctx, request.FieldScheduleIDs, request.OrderID)
+	case constants.Available:
+	default:
+		return errFieldSchedule.ErrInvalidFieldScheduleStatus
+	}
+
 	for _, item := range request.FieldScheduleIDs {
-		_, err := f.repository.GetFieldSchedule().FindByUUID(ctx, item)
+		fieldSchedule, err := f.repository.GetFieldSchedule().FindByUUID(ctx, item)
 		if err != nil {
 			return err
 		}
 
-		err = f.repository.GetFieldSchedule().UpdateStatus(ctx, constants.Booked, item)
+		err = f.release(ctx, fieldSchedule, request.OrderID)
 		if err != nil {
 			return err
 		}
@@ -325,6 +431,48 @@ func (f *FieldScheduleService) UpdateStatus(
 	return nil
 }
 
+// Release the schedule of the order, a released schedule goes to the first customer on the waitlist
+func (f *FieldScheduleService) release(ctx context.Context, fieldSchedule *models.FieldSchedule, orderID uuid.UUID) error {
+	released, err := f.repository.GetFieldSchedule().Release(ctx, fieldSchedule.UUID.String(), orderID)
+	if err != nil {
+		return err
+	}
+	if !released {
+		logrus.Infof("field schedule %s is not held by order %s, leaving it as it is", fieldSchedule.UUID, orderID)
+		return nil
+	}
+
+	err = f.offerNext(ctx, fieldSchedule)
+	if err != nil {
+		logrus.Errorf("failed to offer field schedule %s to the waitlist: %v", fieldSchedule.UUID, err)
+	}
+	return nil
+}
+
+// Hold Field Schedules for an unpaid order
+func (f *FieldScheduleService) Hold(ctx context.Context, request *dto.HoldFieldScheduleRequest) error {
+	// The hold lasts as long as the payment of the order
+	holdTTL := config.Config.HoldTTLInMinutes
+	if holdTTL <= 0 {
+		holdTTL = 60
+	}
+
+	expiredAt := time.Now().Add(time.Duration(holdTTL) * time.Minute)
+	err := f.repository.GetFieldSchedule().Hold(ctx, request.FieldScheduleIDs, expiredAt, request.UserID, request.OrderID)
+	if err != nil {
+		return err
+	}
+
+	// The customer took the schedules offered to them from the waitlist
+	if request.UserID != nil {
+		err = f.repository.GetWaitlist().Claim(ctx, request.FieldScheduleIDs, *request.UserID)
+		if err != nil {
+			logrus.Errorf("failed to claim the waitlist offers of user %s: %v", request.UserID, err)
+		}
+	}
+	return nil
+}
+
 // Delete Field Data
 func (f *FieldScheduleService) Delete(ctx context.Context, uuid string) error {
 	_, err := f.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"common/event"
+	"context"
+	"errors"
+	"field-service/config"
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/fieldschedule"
+	"field-service/domain/dto"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Status of the field schedules after each order event, the other events are ignored
+var orderEventStatuses = map[string]constants.FieldScheduleStatus{
+	event.OrderPaid:                constants.Booked,
+	event.OrderCancelled:           constants.Available,
+	event.OrderExpired:             constants.Available,
+	event.OrderRefunded:            constants.Available,
+	event.OrderOccurrenceCancelled: constants.Available,
+}
+
+// Book or release the field schedules of the order, a redelivered event is applied once.
+// The event is recorded as processed only after the reply to a paid order is published.
+func (f *FieldScheduleService) HandleOrderEvent(
+	ctx context.Context,
+	eventID string,
+	eventType string,
+	request *dto.OrderEventV1,
+) error {
+	status, ok := orderEventStatuses[eventType]
+	if !ok {
+		return nil
+	}
+
+	processed, err := f.repository.GetProcessedEvent().Exists(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if processed {
+		logrus.Infof("order event %s is already processed, ignoring", eventID)
+		return nil
+	}
+
+	// The paid order hears back whether its schedules are booked
+	if status == constants.Booked {
+		err = f.bookOrder(ctx, request)
+	} else {
+		err = f.releaseOrder(ctx, request)
+	}
+	if err != nil {
+		return err
+	}
+
+	return f.repository.GetProcessedEvent().Create(ctx, eventID, eventType)
+}
+
+// Book all of the schedules of the paid order, or none of them when one is taken
+func (f *FieldScheduleService) bookOrder(ctx context.Context, request *dto.OrderEventV1) error {
+	// The reply always carries a list, even for an order without schedules
+	fieldScheduleIDs := append(make([]uuid.UUID, 0, len(request.FieldScheduleIDs)), request.FieldScheduleIDs...)
+	uuids := make([]string, 0, len(request.FieldScheduleIDs))
+	for _, item := range request.FieldScheduleIDs {
+		uuids = append(uuids, item.String())
+	}
+
+	err := f.repository.GetFieldSchedule().Book(ctx, uuids, request.OrderID)
+	if errors.Is(err, errFieldSchedule.ErrFieldScheduleNotAvailable) {
+		logrus.Warnf("field schedules of order %s are not available, failing the booking", request.OrderID)
+		return f.publishEvent(event.FieldScheduleBookingFailed, request.OrderID, dto.FieldScheduleBookingFailedEventV1{
+			OrderID:          request.OrderID,
+			FieldScheduleIDs: fieldScheduleIDs,
+			Reason:           err.Error(),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	return f.publishEvent(event.FieldScheduleBooked, request.OrderID, dto.FieldScheduleBookedEventV1{
+		OrderID:          request.OrderID,
+		FieldScheduleIDs: fieldScheduleIDs,
+	})
+}
+
+// Release the schedules of the closed order. Only the schedules the order still holds or books are released,
+// so a schedule taken by another order after the hold lapsed, or offered to the waitlist, is left as it is.
+func (f *FieldScheduleService) releaseOrder(ctx context.Context, request *dto.OrderEventV1) error {
+	for _, item := range request.FieldScheduleIDs {
+		fieldSchedule, err := f.repository.GetFieldSchedule().FindByUUID(ctx, item.String())
+		if errors.Is(err, errFieldSchedule.ErrFieldScheduleNotFound) {
+			logrus.Warnf("field schedule %s of order %s no longer exists", item, request.OrderID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		err = f.release(ctx, fieldSchedule, request.OrderID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Publish the field schedule event to order-service, keyed by the order so it follows the order events
+func (f *FieldScheduleService) publishEvent(eventType string, orderID uuid.UUID, payload any) error {
+	message, err := event.New(eventType, event.FieldLatestVersion, "field-service", orderID.String(), payload)
+	if err != nil {
+		return err
+	}
+
+	topic := config.Config.Kafka.EventTopic
+	if topic == "" {
+		topic = constants.FieldEventTopic
+	}
+	return f.producer.ProduceMessage(topic, orderID.String(), message)
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	clientNotification "field-service/clients/notification"
+	clientUser "field-service/clients/user"
+	"field-service/config"
+	"field-service/constants"
+	errWaitlist "field-service/constants/error/waitlist"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// How long the first customer on the waitlist has to book a released schedule
+func (f *FieldScheduleService) offerTTL() time.Duration {
+	ttl := time.Duration(config.Config.Waitlist.OfferTTLInMinutes) * time.Minute
+	if ttl <= 0 {
+		ttl = 15 * time.Minute
+	}
+	return ttl
+}
+
+// Join the waitlist of a booked or held schedule
+func (f *FieldScheduleService) JoinWaitlist(ctx context.Context, uuid string) (*dto.WaitlistEntryResponse, error) {
+	user := ctx.Value(constants.User).(*clientUser.UserData)
+	fieldSchedule, err := f.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.currentStatus(fieldSchedule) == constants.AvailableString {
+		return nil, errWaitlist.ErrFieldScheduleIsOpen
+	}
+
+	_, err = f.repository.GetWaitlist().FindActiveByUserID(ctx, fieldSchedule.ID, user.UUID)
+	if err == nil {
+		return nil, errWaitlist.ErrAlreadyOnWaitlist
+	}
+	if err != errWaitlist.ErrWaitlistEntryNotFound {
+		return nil, err
+	}
+
+	entry, err := f.repository.GetWaitlist().Create(ctx, &models.WaitlistEntry{
+		FieldScheduleID: fieldSchedule.ID,
+		UserID:          user.UUID,
+		Name:            user.Name,
+		Email:           user.Email,
+		PhoneNumber:     user.PhoneNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ahead, err := f.repository.GetWaitlist().CountAhead(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WaitlistEntryResponse{
+		UUID:            entry.UUID,
+		FieldScheduleID: fieldSchedule.UUID,
+		Status:          entry.Status.GetStatusString(),
+		Position:        ahead + 1,
+		CreatedAt:       entry.CreatedAt,
+	}, nil
+}
+
+// Leave the waitlist, an offer that is given up goes to the next customer
+func (f *FieldScheduleService) LeaveWaitlist(ctx context.Context, uuid string) error {
+	user := ctx.Value(constants.User).(*clientUser.UserData)
+	fieldSchedule, err := f.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	entry, err := f.repository.GetWaitlist().FindActiveByUserID(ctx, fieldSchedule.ID, user.UUID)
+	if err != nil {
+		return err
+	}
+
+	wasOffered := entry.Status == constants.Offered
+	entry.Status = constants.WaitlistCanceled
+	err = f.repository.GetWaitlist().Update(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if wasOffered && f.isOfferedTo(fieldSchedule, entry) {
+		return f.offerNext(ctx, fieldSchedule)
+	}
+	return nil
+}
+
+// Get the waitlist depth per field and date
+func (f *FieldScheduleService) GetWaitlistDepth(
+	ctx context.Context,
+	param *dto.WaitlistDepthRequestParam,
+) ([]dto.WaitlistDepthResponse, error) {
+	depths, err := f.repository.GetWaitlist().CountDepth(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rows are ordered by date and field, so each group is contiguous
+	responses := make([]dto.WaitlistDepthResponse, 0)
+	for _, depth := range depths {
+		date := depth.Date.Format(time.DateOnly)
+		last := len(responses) - 1
+		if last < 0 || responses[last].FieldID != depth.FieldID || responses[last].Date != date {
+			responses = append(responses, dto.WaitlistDepthResponse{
+				FieldID:   depth.FieldID,
+				FieldName: depth.FieldName,
+				Date:      date,
+			})
+			last++
+		}
+
+		responses[last].Depth += depth.Depth
+		responses[last].Schedules = append(responses[last].Schedules, dto.WaitlistScheduleDepthResponse{
+			UUID:  depth.FieldScheduleID,
+			Time:  fmt.Sprintf("%s - %s", depth.StartTime, depth.EndTime),
+			Depth: depth.Depth,
+		})
+	}
+	return responses, nil
+}
+
+// Expire the offers that were not booked in time and pass the schedules to the next customer (used by the waitlist worker)
+func (f *FieldScheduleService) ExpireWaitlistOffers(ctx context.Context) error {
+	entries, err := f.repository.GetWaitlist().FindAllExpiredOffers(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		entry.Status = constants.OfferExpired
+		err = f.repository.GetWaitlist().Update(ctx, &entry)
+		if err != nil {
+			logrus.Errorf("failed to expire waitlist offer %s: %v", entry.UUID, err)
+			continue
+		}
+
+		if !f.isOfferedTo(&entry.FieldSchedule, &entry) {
+			continue
+		}
+
+		err = f.offerNext(ctx, &entry.FieldSchedule)
+		if err != nil {
+			logrus.Errorf("failed to offer field schedule %s to the waitlist: %v", entry.FieldSchedule.UUID, err)
+		}
+	}
+	return nil
+}
+
+// Whether the schedule is still held for the offer of the entry
+func (f *FieldScheduleService) isOfferedTo(fieldSchedule *models.FieldSchedule, entry *models.WaitlistEntry) bool {
+	return fieldSchedule.Status == constants.Hold &&
+		fieldSchedule.HoldUserID != nil &&
+		*fieldSchedule.HoldUserID == entry.UserID
+}
+
+// Hold the schedule for the first customer on the waitlist and notify them, the schedule is available when nobody waits
+func (f *FieldScheduleService) offerNext(ctx context.Context, fieldSchedule *models.FieldSchedule) error {
+	entry, err := f.repository.GetWaitlist().FindFirstWaiting(ctx, fieldSchedule.ID)
+	if err != nil {
+		return err
+	}
+
+	if entry == nil {
+		if fieldSchedule.HoldUserID == nil {
+			return nil
+		}
+		return f.repository.GetFieldSchedule().UpdateStatus(ctx, constants.Available, fieldSchedule.UUID.String())
+	}
+
+	expiredAt := time.Now().Add(f.offerTTL())
+	err = f.repository.GetFieldSchedule().Offer(ctx, fieldSchedule.ID, entry.UserID, expiredAt)
+	if err != nil {
+		return err
+	}
+
+	entry.Status = constants.Offered
+	entry.OfferExpiredAt = &expiredAt
+	err = f.repository.GetWaitlist().Update(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	// The offer stands even when the notification fails, the customer can still see it in the schedule
+	err = f.notifyOffer(ctx, fieldSchedule, entry)
+	if err != nil {
+		logrus.Errorf("failed to notify waitlist offer %s: %v", entry.UUID, err)
+	}
+	return nil
+}
+
+func (f *FieldScheduleService) notifyOffer(
+	ctx context.Context,
+	fieldSchedule *models.FieldSchedule,
+	entry *models.WaitlistEntry,
+) error {
+	// The schedule of an expired offer is loaded without its field and time
+	if fieldSchedule.Field.ID == 0 || fieldSchedule.Time.ID == 0 {
+		loaded, err := f.repository.GetFieldSchedule().FindByUUID(ctx, fieldSchedule.UUID.String())
+		if err != nil {
+			return err
+		}
+		fieldSchedule = loaded
+	}
+
+	date := fieldSchedule.Date.Format(time.DateOnly)
+	return f.client.GetNotification().Send(ctx, &clientNotification.NotificationRequest{
+		Type:        clientNotification.WaitlistOfferNotification,
+		UserID:      entry.UserID,
+		Name:        entry.Name,
+		Email:       entry.Email,
+		PhoneNumber: entry.PhoneNumber,
+		Message: fmt.Sprintf("Jadwal %s tanggal %s pukul %s - %s tersedia untuk Anda sampai %s",
+			fieldSchedule.Field.Name,
+			date,
+			fieldSchedule.Time.StartTime,
+			fieldSchedule.Time.EndTime,
+			entry.OfferExpiredAt.Format("15:04"),
+		),
+		Data: map[string]any{
+			"fieldScheduleID": fieldSchedule.UUID,
+			"fieldID":         fieldSchedule.Field.UUID,
+			"date":            date,
+			"offerExpiredAt":  entry.OfferExpiredAt,
+		},
+	})
+}
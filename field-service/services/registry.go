@@ -1,6 +1,8 @@
 package services
 
 import (
+	"common/kafka"
+	"field-service/clients"
 	"field-service/common/gcs"
 	"field-service/repositories"
 	fieldService "field-service/services/field"
@@ -11,6 +13,8 @@ import (
 type Registry struct {
 	repository repositories.IRepositoryRegistry
 	gcs        gcs.IGCSClient
+	client     clients.IClientRegistry
+	producer   kafka.IProducer
 }
 
 type IServiceRegistry interface {
@@ -19,10 +23,17 @@ type IServiceRegistry interface {
 	GetTime() timeService.ITimeService
 }
 
-func NewServiceRegistry(repository repositories.IRepositoryRegistry, gcs gcs.IGCSClient) IServiceRegistry {
+func NewServiceRegistry(
+	repository repositories.IRepositoryRegistry,
+	gcs gcs.IGCSClient,
+	client clients.IClientRegistry,
+	producer kafka.IProducer,
+) IServiceRegistry {
 	return &Registry{
 		repository: repository,
 		gcs:        gcs,
+		client:     client,
+		producer:   producer,
 	}
 }
 
@@ -31,7 +42,7 @@ func (r *Registry) GetField() fieldService.IFieldService {
 }
 
 func (r *Registry) GetFieldSchedule() fieldScheduleService.IFieldScheduleService {
-	return fieldScheduleService.NewFieldScheduleService(r.repository)
+	return fieldScheduleService.NewFieldScheduleService(r.repository, r.client, r.producer)
 }
 
 func (r *Registry) GetTime() timeService.ITimeService {
@@ -0,0 +1,28 @@
+package workers
+
+import (
+	"field-service/config"
+	"field-service/services"
+	waitlistWorker "field-service/workers/waitlist"
+	"time"
+)
+
+type Registry struct {
+	service services.IServiceRegistry
+}
+
+type IWorkerRegistry interface {
+	GetWaitlist() waitlistWorker.IWaitlistWorker
+}
+
+func NewWorkerRegistry(service services.IServiceRegistry) IWorkerRegistry {
+	return &Registry{service: service}
+}
+
+func (r *Registry) GetWaitlist() waitlistWorker.IWaitlistWorker {
+	interval := time.Duration(config.Config.Waitlist.OfferSweeperIntervalInSeconds) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return waitlistWorker.NewWaitlistWorker(r.service, interval)
+}
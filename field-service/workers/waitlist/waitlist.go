@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"context"
+	"field-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type WaitlistWorker struct {
+	service  services.IServiceRegistry
+	interval time.Duration
+}
+
+type IWaitlistWorker interface {
+	Start(context.Context)
+}
+
+func NewWaitlistWorker(service services.IServiceRegistry, interval time.Duration) IWaitlistWorker {
+	return &WaitlistWorker{service: service, interval: interval}
+}
+
+// Sweep the waitlist offers that were not booked in time
+func (w *WaitlistWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logrus.Infof("waitlist worker started, interval %s", w.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Infof("waitlist worker stopped")
+			return
+		case <-ticker.C:
+			err := w.service.GetFieldSchedule().ExpireWaitlistOffers(ctx)
+			if err != nil {
+				logrus.Errorf("failed to expire waitlist offers: %v", err)
+			}
+		}
+	}
+}
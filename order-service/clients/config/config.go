@@ -6,12 +6,14 @@ type CLientConfig struct {
 	client       *gorequest.SuperAgent
 	baseURL      string
 	signatureKey string
+	internalKey  string
 }
 
 type IClientConfig interface {
 	Client() *gorequest.SuperAgent
 	BaseURL() string
 	SignatureKey() string
+	InternalKey() string
 }
 
 type Option func(*CLientConfig)
@@ -38,6 +40,11 @@ func (c *CLientConfig) SignatureKey() string {
 	return c.signatureKey
 }
 
+// Key of the internal routes, which only accept the callers on their allowlist
+func (c *CLientConfig) InternalKey() string {
+	return c.internalKey
+}
+
 func WithBaseURL(baseURL string) Option {
 	return func(c *CLientConfig) {
 
@@ -50,3 +57,9 @@ func WithSignatureKey(signatureKey string) Option {
 		c.signatureKey = signatureKey
 	}
 }
+
+func WithInternalKey(internalKey string) Option {
+	return func(c *CLientConfig) {
+		c.internalKey = internalKey
+	}
+}
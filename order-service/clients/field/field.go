@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"order-service/clients/config"
 	"order-service/common/utils"
 	configApp "order-service/config"
 	"order-service/constants"
+	errOrder "order-service/constants/error/order"
 	"order-service/domain/dto"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,6 +25,8 @@ type FieldClient struct {
 type IFieldClient interface {
 	GetFieldByUUID(context.Context, uuid.UUID) (*FieldData, error)
 	UpdateStatus(request *dto.UpdateFieldScheduleStatusRequest) error
+	HoldFieldSchedules(request *dto.HoldFieldScheduleRequest) error
+	LookupFieldSchedules(request *dto.FieldScheduleLookupRequest) ([]FieldData, error)
 }
 
 func NewFieldClient(client config.IClientConfig) IFieldClient {
@@ -64,7 +69,7 @@ func (f *FieldClient) UpdateStatus(request *dto.UpdateFieldScheduleStatusRequest
 	unixTime := time.Now().Unix()
 	generateAPIKey := fmt.Sprintf("%s:%s:%d",
 		configApp.Config.AppName,
-		f.client.SignatureKey(),
+		f.client.InternalKey(),
 		unixTime,
 	)
 	apiKey := utils.GenerateSHA256(generateAPIKey)
@@ -75,7 +80,7 @@ func (f *FieldClient) UpdateStatus(request *dto.UpdateFieldScheduleStatusRequest
 	}
 
 	res, bodyRes, errs := f.client.Client().Clone().
-		Post(fmt.Sprintf("%s/api/v1/field/schedule/status", f.client.BaseURL())).
+		Patch(fmt.Sprintf("%s/api/v1/field/schedule", f.client.BaseURL())).
 		Set(constants.XApiKey, apiKey).
 		Set(constants.XServiceName, configApp.Config.AppName).
 		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime)).
@@ -103,3 +108,80 @@ func (f *FieldClient) UpdateStatus(request *dto.UpdateFieldScheduleStatusRequest
 	}
 	return nil
 }
+
+func (f *FieldClient) HoldFieldSchedules(request *dto.HoldFieldScheduleRequest) error {
+	unixTime := time.Now().Unix()
+	generateAPIKey := fmt.Sprintf("%s:%s:%d",
+		configApp.Config.AppName,
+		f.client.InternalKey(),
+		unixTime,
+	)
+	apiKey := utils.GenerateSHA256(generateAPIKey)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	res, bodyRes, errs := f.client.Client().Clone().
+		Post(fmt.Sprintf("%s/api/v1/field/schedule/hold", f.client.BaseURL())).
+		Set(constants.XApiKey, apiKey).
+		Set(constants.XServiceName, configApp.Config.AppName).
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime)).
+		Send(string(body)).
+		End()
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	var response FieldResponse
+	if res.StatusCode != http.StatusCreated {
+		// The schedules are already held or booked by another order
+		if res.StatusCode == http.StatusConflict {
+			return errOrder.ErrFieldScheduleNotAvailable
+		}
+
+		err = json.Unmarshal([]byte(bodyRes), &response)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("field response: %s", response.Message)
+	}
+	return nil
+}
+
+func (f *FieldClient) LookupFieldSchedules(request *dto.FieldScheduleLookupRequest) ([]FieldData, error) {
+	unixTime := time.Now().Unix()
+	generateAPIKey := fmt.Sprintf("%s:%s:%d",
+		configApp.Config.AppName,
+		f.client.SignatureKey(),
+		unixTime,
+	)
+	apiKey := utils.GenerateSHA256(generateAPIKey)
+
+	query := url.Values{}
+	query.Set("fieldID", request.FieldID)
+	query.Set("timeID", request.TimeID)
+	query.Set("weekday", strconv.Itoa(request.Weekday))
+	query.Set("startDate", request.StartDate)
+	query.Set("endDate", request.EndDate)
+
+	var response FieldListResponse
+	res, _, errs := f.client.Client().Clone().
+		Get(fmt.Sprintf("%s/api/v1/field/schedule/lookup?%s", f.client.BaseURL(), query.Encode())).
+		Set(constants.XApiKey, apiKey).
+		Set(constants.XServiceName, configApp.Config.AppName).
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime)).
+		EndStruct(&response)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("request failed: %v", errs[0])
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, message: %s",
+			res.StatusCode, response.Message)
+	}
+
+	return response.Data, nil
+}
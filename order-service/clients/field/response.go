@@ -13,13 +13,22 @@ type FieldResponse struct {
 	Data    FieldData `json:"Data"`
 }
 
+type FieldListResponse struct {
+	Code    int         `json:"code"`
+	Status  string      `json:"status"`
+	Message string      `json:"Message"`
+	Data    []FieldData `json:"Data"`
+}
+
 type FieldData struct {
 	UUID         uuid.UUID  `json:"uuid"`
+	FieldID      uuid.UUID  `json:"fieldID"`
 	FieldName    string     `json:"fieldName"`
 	PricePerHour float64    `json:"pricePerHour"`
 	Date         string     `json:"date"`
 	StartTime    string     `json:"startTime"`
 	EndTime      string     `json:"endTime"`
+	Time         string     `json:"time"`
 	Status       string     `json:"status"`
 	CreatedAt    *time.Time `json:"createdAt"`
 	UpdatedAt    *time.Time `json:"updatedAt"`
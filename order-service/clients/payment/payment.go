@@ -13,19 +13,29 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
 )
 
 type PaymentClient struct {
 	client config.IClientConfig
+	cache  *cache.Cache
 }
 
 type IPaymentClient interface {
 	GetPaymentByUUID(context.Context, uuid.UUID) (*PaymentData, error)
+	GetPaymentsByUUIDs(context.Context, []uuid.UUID) (map[uuid.UUID]*PaymentData, error)
+	GetPaymentHistory(context.Context, uuid.UUID) ([]PaymentHistoryData, error)
 	CreatePaymentLink(context.Context, *dto.PaymentRequest) (*PaymentData, error)
+	CancelPayment(context.Context, uuid.UUID) (*PaymentData, error)
+	RefundPayment(context.Context, uuid.UUID, *dto.PaymentRefundRequest) error
 }
 
-func NewPaymentClient(client config.IClientConfig) IPaymentClient {
-	return &PaymentClient{client: client}
+func NewPaymentClient(client config.IClientConfig, cache *cache.Cache) IPaymentClient {
+	return &PaymentClient{client: client, cache: cache}
+}
+
+func paymentCacheKey(uuid uuid.UUID) string {
+	return fmt.Sprintf("payment:%s", uuid)
 }
 
 func (p *PaymentClient) GetPaymentByUUID(ctx context.Context, uuid uuid.UUID) (*PaymentData, error) {
@@ -59,30 +69,114 @@ func (p *PaymentClient) GetPaymentByUUID(ctx context.Context, uuid uuid.UUID) (*
 	return &response.Data, nil
 }
 
+// Get the payments in one request, only the ones missing from the cache are requested
+func (p *PaymentClient) GetPaymentsByUUIDs(ctx context.Context, uuids []uuid.UUID) (map[uuid.UUID]*PaymentData, error) {
+	payments := make(map[uuid.UUID]*PaymentData, len(uuids))
+	missing := make([]string, 0, len(uuids))
+	for _, item := range uuids {
+		if _, ok := payments[item]; ok {
+			continue
+		}
+		if cached, found := p.cache.Get(paymentCacheKey(item)); found {
+			payments[item] = cached.(*PaymentData)
+			continue
+		}
+		missing = append(missing, item.String())
+	}
+
+	if len(missing) == 0 {
+		return payments, nil
+	}
+
+	unixTime := time.Now().Unix()
+	generateAPIKey := fmt.Sprintf("%s:%s:%d",
+		configApp.Config.AppName,
+		p.client.InternalKey(),
+		unixTime,
+	)
+	apiKey := utils.GenerateSHA256(generateAPIKey)
+
+	body, err := json.Marshal(&BatchPaymentRequest{UUIDs: missing})
+	if err != nil {
+		return nil, err
+	}
+
+	var response BatchPaymentResponse
+	request := p.client.Client().Clone().
+		Post(fmt.Sprintf("%s/api/v1/payment:batch", p.client.BaseURL())).
+		Set(constants.XApiKey, apiKey).
+		Set(constants.XServiceName, configApp.Config.AppName).
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime))
+
+	res, _, errs := request.Send(string(body)).EndStruct(&response)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("payment response: %s", response.Message)
+	}
+
+	for i := range response.Data {
+		payment := &response.Data[i]
+		p.cache.SetDefault(paymentCacheKey(payment.UUID), payment)
+		payments[payment.UUID] = payment
+	}
+	return payments, nil
+}
+
+func (p *PaymentClient) GetPaymentHistory(ctx context.Context, uuid uuid.UUID) ([]PaymentHistoryData, error) {
+	unixTime := time.Now().Unix()
+	generateAPIKey := fmt.Sprintf("%s:%s:%d",
+		configApp.Config.AppName,
+		p.client.InternalKey(),
+		unixTime,
+	)
+	apiKey := utils.GenerateSHA256(generateAPIKey)
+
+	var response PaymentHistoryResponse
+	request := p.client.Client().Clone().
+		Set(constants.XApiKey, apiKey).
+		Set(constants.XServiceName, configApp.Config.AppName).
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime)).
+		Get(fmt.Sprintf("%s/api/v1/payment/%s/history/internal", p.client.BaseURL(), uuid))
+
+	res, _, errs := request.EndStruct(&response)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("payment response: %s", response.Message)
+	}
+
+	return response.Data, nil
+}
+
 func (p *PaymentClient) CreatePaymentLink(ctx context.Context, req *dto.PaymentRequest) (*PaymentData, error) {
 	unixTime := time.Now().Unix()
 	generateAPIKey := fmt.Sprintf("%s:%s:%d",
 		configApp.Config.AppName,
-		p.client.SignatureKey(),
+		p.client.InternalKey(),
 		unixTime,
 	)
 	apiKey := utils.GenerateSHA256(generateAPIKey)
-	token := ctx.Value(constants.Token).(string)
-	bearerToken := fmt.Sprintf("Bearer %s", token)
 
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
 
-	res, bodyRes, errs := p.client.Client().Clone().
+	request := p.client.Client().Clone().
 		Post(fmt.Sprintf("%s/api/v1/payment", p.client.BaseURL())).
-		Set(constants.Authorization, bearerToken).
 		Set(constants.XApiKey, apiKey).
 		Set(constants.XServiceName, configApp.Config.AppName).
-		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime)).
-		Send(string(body)).
-		End()
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime))
+	if req.IdempotencyKey != "" {
+		request = request.Set(constants.IdempotencyKey, req.IdempotencyKey)
+	}
+
+	res, bodyRes, errs := request.Send(string(body)).End()
 
 	if len(errs) > 0 {
 		return nil, errs[0]
@@ -106,3 +200,63 @@ func (p *PaymentClient) CreatePaymentLink(ctx context.Context, req *dto.PaymentR
 
 	return &response.Data, nil
 }
+
+func (p *PaymentClient) CancelPayment(ctx context.Context, uuid uuid.UUID) (*PaymentData, error) {
+	unixTime := time.Now().Unix()
+	generateAPIKey := fmt.Sprintf("%s:%s:%d",
+		configApp.Config.AppName,
+		p.client.InternalKey(),
+		unixTime,
+	)
+	apiKey := utils.GenerateSHA256(generateAPIKey)
+
+	var response PaymentResponse
+	request := p.client.Client().Clone().
+		Post(fmt.Sprintf("%s/api/v1/payment/%s/cancel", p.client.BaseURL(), uuid)).
+		Set(constants.XApiKey, apiKey).
+		Set(constants.XServiceName, configApp.Config.AppName).
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime))
+
+	res, _, errs := request.EndStruct(&response)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("payment response: %s", response.Message)
+	}
+
+	return &response.Data, nil
+}
+
+func (p *PaymentClient) RefundPayment(ctx context.Context, uuid uuid.UUID, req *dto.PaymentRefundRequest) error {
+	unixTime := time.Now().Unix()
+	generateAPIKey := fmt.Sprintf("%s:%s:%d",
+		configApp.Config.AppName,
+		p.client.InternalKey(),
+		unixTime,
+	)
+	apiKey := utils.GenerateSHA256(generateAPIKey)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	var response PaymentResponse
+	request := p.client.Client().Clone().
+		Post(fmt.Sprintf("%s/api/v1/payment/%s/refund/internal", p.client.BaseURL(), uuid)).
+		Set(constants.XApiKey, apiKey).
+		Set(constants.XServiceName, configApp.Config.AppName).
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime))
+
+	res, _, errs := request.Send(string(body)).EndStruct(&response)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	if res.StatusCode != http.StatusCreated {
+		return fmt.Errorf("payment response: %s", response.Message)
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package clients
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type PaymentResponse struct {
 	Code    string      `json:"code"`
@@ -26,3 +30,26 @@ type PaymentData struct {
 	CreatedAt     string    `json:"createdAt"`
 	UpdatedAt     string    `json:"updatedAt"`
 }
+
+type BatchPaymentRequest struct {
+	UUIDs []string `json:"uuids"`
+}
+
+type BatchPaymentResponse struct {
+	Code    string        `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Data    []PaymentData `json:"data"`
+}
+
+type PaymentHistoryResponse struct {
+	Code    string               `json:"code"`
+	Status  string               `json:"status"`
+	Message string               `json:"message"`
+	Data    []PaymentHistoryData `json:"data"`
+}
+
+type PaymentHistoryData struct {
+	Status    string     `json:"status"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
@@ -6,9 +6,14 @@ import (
 	paymentClients "order-service/clients/payment"
 	clients "order-service/clients/user"
 	configApp "order-service/config"
+	"time"
+
+	"github.com/patrickmn/go-cache"
 )
 
-type ClientRegistry struct{}
+type ClientRegistry struct {
+	cache *cache.Cache
+}
 
 type IClientRegistry interface {
 	GetUser() clients.IUserClient
@@ -17,7 +22,12 @@ type IClientRegistry interface {
 }
 
 func NewClientRegistry() IClientRegistry {
-	return &ClientRegistry{}
+	// Short lived cache shared by the clients, so list pages do not look up the same data again
+	ttl := time.Duration(configApp.Config.InternalService.CacheTTLInSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = 30 * time.Second
+	}
+	return &ClientRegistry{cache: cache.New(ttl, 2*ttl)}
 }
 
 // Get User
@@ -26,7 +36,8 @@ func (c *ClientRegistry) GetUser() clients.IUserClient {
 		config.NewClientConfig(
 			config.WithBaseURL(configApp.Config.InternalService.User.Host),
 			config.WithSignatureKey(configApp.Config.InternalService.User.SignatureKey),
-		))
+			config.WithInternalKey(configApp.Config.InternalService.User.InternalKey),
+		), c.cache)
 }
 
 // Get Payment
@@ -35,7 +46,8 @@ func (c *ClientRegistry) GetPayment() paymentClients.IPaymentClient {
 		config.NewClientConfig(
 			config.WithBaseURL(configApp.Config.InternalService.Payment.Host),
 			config.WithSignatureKey(configApp.Config.InternalService.Payment.SignatureKey),
-		))
+			config.WithInternalKey(configApp.Config.InternalService.Payment.InternalKey),
+		), c.cache)
 }
 
 // Get Field
@@ -44,5 +56,6 @@ func (c *ClientRegistry) GetField() fieldClients.IFieldClient {
 		config.NewClientConfig(
 			config.WithBaseURL(configApp.Config.InternalService.Field.Host),
 			config.WithSignatureKey(configApp.Config.InternalService.Field.SignatureKey),
+			config.WithInternalKey(configApp.Config.InternalService.Field.InternalKey),
 		))
 }
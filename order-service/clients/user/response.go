@@ -17,3 +17,19 @@ type UserData struct {
 	PhoneNumber string    `json:"phoneNumber"`
 	Username    string    `json:"username"`
 }
+
+type BatchUserRequest struct {
+	UUIDs []string `json:"uuids"`
+}
+
+type BatchUserResponse struct {
+	Code    int             `json:"code"`
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Data    []BatchUserData `json:"data"`
+}
+
+type BatchUserData struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
@@ -2,6 +2,7 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"order-service/clients/config"
@@ -11,19 +12,30 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
 )
 
 type UserClient struct {
 	client config.IClientConfig
+	cache  *cache.Cache
 }
 
 type IUserClient interface {
 	GetUserByToken(context.Context) (*UserData, error)
 	GetUserByUUID(context.Context, uuid.UUID) (*UserData, error)
+	GetUserNamesByUUIDs(context.Context, []uuid.UUID) (map[uuid.UUID]string, error)
 }
 
-func NewUserClient(client config.IClientConfig) IUserClient {
-	return &UserClient{client: client}
+func NewUserClient(client config.IClientConfig, cache *cache.Cache) IUserClient {
+	return &UserClient{client: client, cache: cache}
+}
+
+func userCacheKey(uuid uuid.UUID) string {
+	return fmt.Sprintf("user:%s", uuid)
+}
+
+func userNameCacheKey(uuid uuid.UUID) string {
+	return fmt.Sprintf("user-name:%s", uuid)
 }
 
 func (u *UserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
@@ -59,6 +71,10 @@ func (u *UserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
 }
 
 func (u *UserClient) GetUserByUUID(ctx context.Context, uuid uuid.UUID) (*UserData, error) {
+	if cached, found := u.cache.Get(userCacheKey(uuid)); found {
+		return cached.(*UserData), nil
+	}
+
 	unixTime := time.Now().Unix()
 	generateAPIKey := fmt.Sprintf("%s:%s:%d",
 		configApp.Config.AppName,
@@ -87,5 +103,62 @@ func (u *UserClient) GetUserByUUID(ctx context.Context, uuid uuid.UUID) (*UserDa
 			res.StatusCode, response.Message)
 	}
 
+	u.cache.SetDefault(userCacheKey(uuid), &response.Data)
 	return &response.Data, nil
 }
+
+// Get the names of the users in one request, only the ones missing from the cache are requested
+func (u *UserClient) GetUserNamesByUUIDs(ctx context.Context, uuids []uuid.UUID) (map[uuid.UUID]string, error) {
+	names := make(map[uuid.UUID]string, len(uuids))
+	missing := make([]string, 0, len(uuids))
+	for _, item := range uuids {
+		if _, ok := names[item]; ok {
+			continue
+		}
+		if cached, found := u.cache.Get(userNameCacheKey(item)); found {
+			names[item] = cached.(string)
+			continue
+		}
+		missing = append(missing, item.String())
+	}
+
+	if len(missing) == 0 {
+		return names, nil
+	}
+
+	unixTime := time.Now().Unix()
+	generateAPIKey := fmt.Sprintf("%s:%s:%d",
+		configApp.Config.AppName,
+		u.client.InternalKey(),
+		unixTime,
+	)
+	apiKey := utils.GenerateSHA256(generateAPIKey)
+
+	body, err := json.Marshal(&BatchUserRequest{UUIDs: missing})
+	if err != nil {
+		return nil, err
+	}
+
+	var response BatchUserResponse
+	request := u.client.Client().Clone().
+		Post(fmt.Sprintf("%s/api/v1/auth/users:batch", u.client.BaseURL())).
+		Set(constants.XApiKey, apiKey).
+		Set(constants.XServiceName, configApp.Config.AppName).
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime))
+
+	res, _, errs := request.Send(string(body)).EndStruct(&response)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("request failed: %v", errs[0])
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, message: %s",
+			res.StatusCode, response.Message)
+	}
+
+	for _, user := range response.Data {
+		u.cache.SetDefault(userNameCacheKey(user.UUID), user.Name)
+		names[user.UUID] = user.Name
+	}
+	return names, nil
+}
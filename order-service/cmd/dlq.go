@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	commonKafka "common/kafka"
+	"fmt"
+	"order-service/config"
+	kafkaConfig "order-service/controllers/kafka/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dlqLimit     int
+	dlqPartition int32
+	dlqOffset    int64
+	dlqAll       bool
+)
+
+var dlqCommand = &cobra.Command{
+	Use:   "dlq",
+	Short: "Inspect and replay the dead letter topic",
+}
+
+var dlqListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List the messages on the dead letter topic",
+	Run: func(c *cobra.Command, args []string) {
+		config.Init()
+		deadLetter := commonKafka.NewDeadLetter(kafkaConfig.NewConfig(), config.Config.Kafka.Brokers, config.Config.Kafka.DeadLetterTopic)
+		defer deadLetter.Close()
+
+		messages, err := deadLetter.List(dlqLimit)
+		if err != nil {
+			logrus.Fatalf("failed to list dead letter topic: %v", err)
+		}
+
+		for _, message := range messages {
+			fmt.Printf("partition=%d offset=%d topic=%s attempts=%s failedAt=%s error=%q\n%s\n\n",
+				message.Partition,
+				message.Offset,
+				message.OriginalTopic,
+				message.Attempts,
+				message.FailedAt,
+				message.Error,
+				message.Value,
+			)
+		}
+		fmt.Printf("%d message(s)\n", len(messages))
+	},
+}
+
+var dlqReplayCommand = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay dead letter messages back to their original topic",
+	Run: func(c *cobra.Command, args []string) {
+		config.Init()
+		deadLetter := commonKafka.NewDeadLetter(kafkaConfig.NewConfig(), config.Config.Kafka.Brokers, config.Config.Kafka.DeadLetterTopic)
+		defer deadLetter.Close()
+
+		if dlqAll {
+			total, err := deadLetter.ReplayAll()
+			if err != nil {
+				logrus.Fatalf("failed to replay dead letter topic after %d message(s): %v", total, err)
+			}
+			fmt.Printf("%d message(s) replayed\n", total)
+			return
+		}
+
+		if dlqOffset < 0 {
+			logrus.Fatalf("either --offset or --all is required")
+		}
+		err := deadLetter.Replay(dlqPartition, dlqOffset)
+		if err != nil {
+			logrus.Fatalf("failed to replay message: %v", err)
+		}
+		fmt.Printf("message %d/%d replayed\n", dlqPartition, dlqOffset)
+	},
+}
+
+func init() {
+	dlqListCommand.Flags().IntVar(&dlqLimit, "limit", 100, "maximum number of messages to list, 0 for all")
+	dlqReplayCommand.Flags().Int32Var(&dlqPartition, "partition", 0, "partition of the message to replay")
+	dlqReplayCommand.Flags().Int64Var(&dlqOffset, "offset", -1, "offset of the message to replay")
+	dlqReplayCommand.Flags().BoolVar(&dlqAll, "all", false, "replay every message on the dead letter topic")
+
+	dlqCommand.AddCommand(dlqListCommand, dlqReplayCommand)
+	command.AddCommand(dlqCommand)
+}
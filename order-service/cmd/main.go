@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	commonKafka "common/kafka"
 	"context"
 	"fmt"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"order-service/repositories"
 	"order-service/routes"
 	"order-service/services"
+	"order-service/workers"
 	"os"
 	"os/signal"
 	"syscall"
@@ -49,14 +51,25 @@ var command = &cobra.Command{
 			&models.Order{},
 			&models.OrderHistory{},
 			&models.OrderField{},
+			&models.OrderOutbox{},
+			&models.OrderSaga{},
+			&models.OrderSagaStep{},
+			&models.IdempotencyKey{},
+			&models.ProcessedEvent{},
+			&models.OrderSeries{},
+			&models.OrderPaymentShare{},
+			&models.Voucher{},
+			&models.VoucherUsage{},
 		)
 
 		client := clients.NewClientRegistry()
 		repository := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repository, client)
+		producer := commonKafka.NewKafkaProducer(kafkaConfig.NewConfig(), config.Config.Kafka.Brokers)
+		service := services.NewServiceRegistry(repository, client, producer)
 		controller := controllers.NewControllerRegistry(service)
 
 		serveHttp(controller, client)
+		serveWorker(service)
 		serveKafkaConsumer(service)
 	},
 }
@@ -111,6 +124,14 @@ func serveHttp(controller controllers.IControllerRegistry, client clients.IClien
 	}()
 }
 
+func serveWorker(service services.IServiceRegistry) {
+	worker := workers.NewWorkerRegistry(service)
+
+	// go routines for background workers
+	go worker.GetExpiry().Start(context.Background())
+	go worker.GetOutbox().Start(context.Background())
+}
+
 func serveKafkaConsumer(service services.IServiceRegistry) {
 	kafkaConsumerConfig := sarama.NewConfig()
 	kafkaConsumerConfig.Consumer.MaxWaitTime = time.Duration(config.Config.Kafka.MaxWaitTimeInMs) * time.Millisecond
@@ -135,7 +156,9 @@ func serveKafkaConsumer(service services.IServiceRegistry) {
 	// for closing the Consumer Group
 	defer consumerGroup.Close()
 
-	consumer := kafkaConfig.NewConsumerGroup()
+	deadLetter := commonKafka.NewDeadLetter(kafkaConfig.NewConfig(), brokers, config.Config.Kafka.DeadLetterTopic)
+	defer deadLetter.Close()
+	consumer := commonKafka.NewConsumerGroup(kafkaConfig.NewConfig(), deadLetter)
 	kafkaRegistry := kafka.NewKafkaRegistry(service)
 	kafkaConsumer := kafkaConfig.NewKafkaConsumer(consumer, kafkaRegistry)
 	kafkaConsumer.Register()
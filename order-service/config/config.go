@@ -11,27 +11,31 @@ import (
 var Config AppConfig
 
 type AppConfig struct {
-	Port                       int             `json:"port"`
-	AppName                    string          `json:"appName"`
-	AppEnv                     string          `json:"appEnv"`
-	SignatureKey               string          `json:"signatureKey"`
-	Database                   Database        `json:"database"`
-	RateLimiterMaxRequest      float64         `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond      int             `json:"rateLimiterTimeSecond"`
-	InternalService            InternalService `json:"internalService"`
-	GCSType                    string          `json:"gcsType"`
-	GCSProjectID               string          `json:"gcsProjectID"`
-	GCSPrivateKeyID            string          `json:"gcsPrivateKeyID"`
-	GCSPrivateKey              string          `json:"gcsPrivateKey"`
-	GCSClientEmail             string          `json:"gcsClientEmail"`
-	GCSClientID                string          `json:"gcsClientID"`
-	GCSAuthURI                 string          `json:"gcsAuthURI"`
-	GCSTokenURI                string          `json:"gcsTokenURI"`
-	GCSAuthProviderX509CertURL string          `json:"gcsAuthProviderX509CertURL"`
-	GCSClientX509CertURL       string          `json:"gcsClientX509CertURL"`
-	GCSUniverseDomain          string          `json:"gcsUniverseDomain"`
-	GCSBucketName              string          `json:"gcsBucketName"`
-	Kafka                      Kafka           `json:"kafka"`
+	Port                             int             `json:"port"`
+	AppName                          string          `json:"appName"`
+	AppEnv                           string          `json:"appEnv"`
+	SignatureKey                     string          `json:"signatureKey"`
+	Database                         Database        `json:"database"`
+	RateLimiterMaxRequest            float64         `json:"rateLimiterMaxRequest"`
+	RateLimiterTimeSecond            int             `json:"rateLimiterTimeSecond"`
+	InternalService                  InternalService `json:"internalService"`
+	GCSType                          string          `json:"gcsType"`
+	GCSProjectID                     string          `json:"gcsProjectID"`
+	GCSPrivateKeyID                  string          `json:"gcsPrivateKeyID"`
+	GCSPrivateKey                    string          `json:"gcsPrivateKey"`
+	GCSClientEmail                   string          `json:"gcsClientEmail"`
+	GCSClientID                      string          `json:"gcsClientID"`
+	GCSAuthURI                       string          `json:"gcsAuthURI"`
+	GCSTokenURI                      string          `json:"gcsTokenURI"`
+	GCSAuthProviderX509CertURL       string          `json:"gcsAuthProviderX509CertURL"`
+	GCSClientX509CertURL             string          `json:"gcsClientX509CertURL"`
+	GCSUniverseDomain                string          `json:"gcsUniverseDomain"`
+	GCSBucketName                    string          `json:"gcsBucketName"`
+	Kafka                            Kafka           `json:"kafka"`
+	OrderCancellationWindowInMinutes int             `json:"orderCancellationWindowInMinutes"`
+	OrderHoldTTLInMinutes            int             `json:"orderHoldTTLInMinutes"`
+	IdempotencyLockInSeconds         int             `json:"idempotencyLockInSeconds"`
+	Worker                           Worker          `json:"worker"`
 }
 
 type Database struct {
@@ -47,24 +51,28 @@ type Database struct {
 }
 
 type InternalService struct {
-	User    User    `json:"user"`
-	Field   Field   `json:"field"`
-	Payment Payment `json:"payment"`
+	User              User    `json:"user"`
+	Field             Field   `json:"field"`
+	Payment           Payment `json:"payment"`
+	CacheTTLInSeconds int     `json:"cacheTTLInSeconds"`
 }
 
 type User struct {
 	Host         string `json:"host"`
 	SignatureKey string `json:"signatureKey"`
+	InternalKey  string `json:"internalKey"`
 }
 
 type Field struct {
 	Host         string `json:"host"`
 	SignatureKey string `json:"signatureKey"`
+	InternalKey  string `json:"internalKey"`
 }
 
 type Payment struct {
 	Host         string `json:"host"`
 	SignatureKey string `json:"signatureKey"`
+	InternalKey  string `json:"internalKey"`
 }
 
 type Kafka struct {
@@ -76,6 +84,18 @@ type Kafka struct {
 	MaxWaitTimeInMs       int      `json:"maxWaitTimeInMs"`
 	MaxProcessingTimeInMs int      `json:"maxProcessingTimeInMs"`
 	BackOffTimeInMs       int      `json:"backoffTimeInMs"`
+	MaxBackOffTimeInMs    int      `json:"maxBackoffTimeInMs"`
+	DeadLetterTopic       string   `json:"deadLetterTopic"`
+	EventTopic            string   `json:"eventTopic"`
+}
+
+type Worker struct {
+	ExpirySweeperIntervalInSeconds int `json:"expirySweeperIntervalInSeconds"`
+	OutboxRelayIntervalInSeconds   int `json:"outboxRelayIntervalInSeconds"`
+	OutboxBatchSize                int `json:"outboxBatchSize"`
+	OutboxMaxAttempts              int `json:"outboxMaxAttempts"`
+	OutboxLeaseInSeconds           int `json:"outboxLeaseInSeconds"`
+	SagaStuckAfterInMinutes        int `json:"sagaStuckAfterInMinutes"`
 }
 
 func Init() {
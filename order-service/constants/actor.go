@@ -0,0 +1,14 @@
+package constants
+
+type OrderActor string
+
+const (
+	CustomerActor OrderActor = "customer"
+	AdminActor    OrderActor = "admin"
+	PaymentActor  OrderActor = "payment"
+	SystemActor   OrderActor = "system"
+)
+
+func (a OrderActor) String() string {
+	return string(a)
+}
@@ -2,17 +2,20 @@ package error
 
 import (
 	errOrder "order-service/constants/error/order"
+	errVoucher "order-service/constants/error/voucher"
 )
 
 func ErrMapping(err error) bool {
 	var (
 		GeneralErrors = GeneralErrors
 		OrderErrors   = errOrder.OrderErrors
+		VoucherErrors = errVoucher.VoucherErrors
 	)
 
 	allErrors := make([]error, 0)
 	allErrors = append(allErrors, GeneralErrors...)
 	allErrors = append(allErrors, OrderErrors...)
+	allErrors = append(allErrors, VoucherErrors...)
 
 	for _, item := range allErrors {
 		if err.Error() == item.Error() {
@@ -3,11 +3,31 @@ package error
 import "errors"
 
 var (
-	ErrOrderNotFound      = errors.New("order not found")
-	ErrFiledAlreadyBooked = errors.New("filed schedule already booked")
+	ErrOrderNotFound               = errors.New("order not found")
+	ErrFiledAlreadyBooked          = errors.New("filed schedule already booked")
+	ErrOrderCannotBeCancelled      = errors.New("order cannot be cancelled")
+	ErrCancellationWindowExpired   = errors.New("cancellation window has expired")
+	ErrFieldScheduleNotAvailable   = errors.New("field schedule is not available")
+	ErrInvalidStatusTransition     = errors.New("invalid order status transition")
+	ErrTransitionNotAllowed        = errors.New("not allowed to change the order status")
+	ErrInvalidSeriesDateRange      = errors.New("invalid recurring order date range")
+	ErrNoAvailableOccurrence       = errors.New("no available occurrence in the recurring order")
+	ErrOccurrenceNotFound          = errors.New("occurrence not found")
+	ErrOccurrenceCannotBeCancelled = errors.New("occurrence cannot be cancelled")
+	ErrShareAmountTooSmall         = errors.New("order amount is too small to be split")
 )
 
 var OrderErrors = []error{
 	ErrOrderNotFound,
 	ErrFiledAlreadyBooked,
+	ErrOrderCannotBeCancelled,
+	ErrCancellationWindowExpired,
+	ErrFieldScheduleNotAvailable,
+	ErrInvalidStatusTransition,
+	ErrTransitionNotAllowed,
+	ErrInvalidSeriesDateRange,
+	ErrNoAvailableOccurrence,
+	ErrOccurrenceNotFound,
+	ErrOccurrenceCannotBeCancelled,
+	ErrShareAmountTooSmall,
 }
@@ -0,0 +1,27 @@
+package error
+
+import "errors"
+
+var (
+	ErrVoucherNotFound          = errors.New("voucher not found")
+	ErrVoucherAlreadyExist      = errors.New("voucher code already exist")
+	ErrVoucherNotActive         = errors.New("voucher is not active")
+	ErrVoucherMinSpendNotMet    = errors.New("order amount is below the voucher minimum spend")
+	ErrVoucherUsageLimitReached = errors.New("voucher usage limit has been reached")
+	ErrVoucherNotApplicable     = errors.New("voucher is not applicable to the field schedules")
+	ErrInvalidVoucherPeriod     = errors.New("invalid voucher validity period")
+	ErrInvalidVoucherValue      = errors.New("percentage voucher value must not exceed 100")
+	ErrVoucherCoversWholeOrder  = errors.New("voucher must leave an amount to pay")
+)
+
+var VoucherErrors = []error{
+	ErrVoucherNotFound,
+	ErrVoucherAlreadyExist,
+	ErrVoucherNotActive,
+	ErrVoucherMinSpendNotMet,
+	ErrVoucherUsageLimitReached,
+	ErrVoucherNotApplicable,
+	ErrInvalidVoucherPeriod,
+	ErrInvalidVoucherValue,
+	ErrVoucherCoversWholeOrder,
+}
@@ -3,8 +3,12 @@ package constants
 type FieldStatusString string
 
 const (
-	AvailableStatus FieldStatusString = "pending"
-	BookedStatus    FieldStatusString = "settlement"
+	AvailableStatus FieldStatusString = "Available"
+	BookedStatus    FieldStatusString = "Booked"
+	HoldStatus      FieldStatusString = "Hold"
+
+	// No schedule is generated for the date
+	UnscheduledStatus FieldStatusString = "Unscheduled"
 )
 
 func (p FieldStatusString) String() string {
@@ -0,0 +1,26 @@
+package constants
+
+type OutboxStatus int
+type OutboxEventType string
+
+const (
+	OutboxPending   OutboxStatus = 100
+	OutboxProcessed OutboxStatus = 200
+	OutboxFailed    OutboxStatus = 300
+
+	CreatePaymentLinkEvent OutboxEventType = "create-payment-link"
+	OrderEvent             OutboxEventType = "order-event"
+	RefundPaymentEvent     OutboxEventType = "refund-payment"
+	CancelPaymentEvent     OutboxEventType = "cancel-payment"
+)
+
+// Topic of the order events when the config has none
+const OrderEventTopic = "order-service-event"
+
+func (o OutboxStatus) Int() int {
+	return int(o)
+}
+
+func (o OutboxEventType) String() string {
+	return string(o)
+}
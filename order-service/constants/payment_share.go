@@ -0,0 +1,34 @@
+package constants
+
+type PaymentShareStatus int
+type PaymentShareStatusString string
+
+const (
+	SharePending   PaymentShareStatus = 100
+	SharePaid      PaymentShareStatus = 200
+	ShareExpired   PaymentShareStatus = 300
+	ShareCancelled PaymentShareStatus = 400
+	ShareRefunded  PaymentShareStatus = 500
+
+	SharePendingString   PaymentShareStatusString = "pending"
+	SharePaidString      PaymentShareStatusString = "paid"
+	ShareExpiredString   PaymentShareStatusString = "expired"
+	ShareCancelledString PaymentShareStatusString = "cancelled"
+	ShareRefundedString  PaymentShareStatusString = "refunded"
+)
+
+var mapShareStatusIntToString = map[PaymentShareStatus]PaymentShareStatusString{
+	SharePending:   SharePendingString,
+	SharePaid:      SharePaidString,
+	ShareExpired:   ShareExpiredString,
+	ShareCancelled: ShareCancelledString,
+	ShareRefunded:  ShareRefundedString,
+}
+
+func (p PaymentShareStatus) Int() int {
+	return int(p)
+}
+
+func (p PaymentShareStatus) GetStatusString() PaymentShareStatusString {
+	return mapShareStatusIntToString[p]
+}
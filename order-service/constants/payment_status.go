@@ -3,7 +3,14 @@ package constants
 type PaymentStatusString string
 
 const (
-	PendingPaymentStatus    PaymentStatusString = "pending"
-	SettlementPaymentStatus PaymentStatusString = "settlement"
-	ExpirePaymentStatus     PaymentStatusString = "expire"
+	PendingPaymentStatus       PaymentStatusString = "pending"
+	SettlementPaymentStatus    PaymentStatusString = "settlement"
+	ExpirePaymentStatus        PaymentStatusString = "expire"
+	CapturePaymentStatus       PaymentStatusString = "capture"
+	ChallengePaymentStatus     PaymentStatusString = "challenge"
+	DenyPaymentStatus          PaymentStatusString = "deny"
+	CancelPaymentStatus        PaymentStatusString = "cancel"
+	FailurePaymentStatus       PaymentStatusString = "failure"
+	RefundPaymentStatus        PaymentStatusString = "refund"
+	PartialRefundPaymentStatus PaymentStatusString = "partial_refund"
 )
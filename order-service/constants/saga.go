@@ -0,0 +1,53 @@
+package constants
+
+type SagaStep string
+type SagaStatus int
+type SagaStatusString string
+type SagaStepStatus string
+
+const (
+	ReserveSlotStep    SagaStep = "reserve-slot"
+	CreatePaymentStep  SagaStep = "create-payment"
+	ConfirmPaymentStep SagaStep = "confirm-payment"
+	BookSlotStep       SagaStep = "book-slot"
+
+	SagaRunning      SagaStatus = 100
+	SagaCompleted    SagaStatus = 200
+	SagaCompensating SagaStatus = 300
+	SagaCompensated  SagaStatus = 400
+	SagaFailed       SagaStatus = 500
+
+	SagaRunningString      SagaStatusString = "running"
+	SagaCompletedString    SagaStatusString = "completed"
+	SagaCompensatingString SagaStatusString = "compensating"
+	SagaCompensatedString  SagaStatusString = "compensated"
+	SagaFailedString       SagaStatusString = "failed"
+
+	SagaStepSucceeded   SagaStepStatus = "succeeded"
+	SagaStepFailed      SagaStepStatus = "failed"
+	SagaStepCompensated SagaStepStatus = "compensated"
+)
+
+// Steps of the booking saga in the order they run
+var SagaSteps = []SagaStep{
+	ReserveSlotStep,
+	CreatePaymentStep,
+	ConfirmPaymentStep,
+	BookSlotStep,
+}
+
+var mapSagaStatusIntToString = map[SagaStatus]SagaStatusString{
+	SagaRunning:      SagaRunningString,
+	SagaCompleted:    SagaCompletedString,
+	SagaCompensating: SagaCompensatingString,
+	SagaCompensated:  SagaCompensatedString,
+	SagaFailed:       SagaFailedString,
+}
+
+func (s SagaStatus) Int() int {
+	return int(s)
+}
+
+func (s SagaStatus) GetStatusString() SagaStatusString {
+	return mapSagaStatusIntToString[s]
+}
@@ -4,29 +4,56 @@ type OrderStatus int
 type OrderStatusString string
 
 const (
-	Pending        OrderStatus = 100
-	PendingPayment OrderStatus = 200
-	PaymentSuccess OrderStatus = 300
-	Expired        OrderStatus = 400
-
-	PendingString        OrderStatusString = "pending"
-	PendingPaymentString OrderStatusString = "pending-payment"
-	PaymentSuccessString OrderStatusString = "payment-success"
-	ExpiredString        OrderStatusString = "expired"
+	Pending           OrderStatus = 100
+	PendingPayment    OrderStatus = 200
+	PaymentChallenged OrderStatus = 250
+	PaymentSuccess    OrderStatus = 300
+	Expired           OrderStatus = 400
+	Cancelled         OrderStatus = 500
+	Refunded          OrderStatus = 600
+	PartiallyRefunded OrderStatus = 650
+	Completed         OrderStatus = 700
+	NoShow            OrderStatus = 800
+
+	PendingString           OrderStatusString = "pending"
+	PendingPaymentString    OrderStatusString = "pending-payment"
+	PaymentChallengedString OrderStatusString = "payment-challenged"
+	PaymentSuccessString    OrderStatusString = "payment-success"
+	ExpiredString           OrderStatusString = "expired"
+	CancelledString         OrderStatusString = "cancelled"
+	RefundedString          OrderStatusString = "refunded"
+	PartiallyRefundedString OrderStatusString = "partially-refunded"
+	CompletedString         OrderStatusString = "completed"
+	NoShowString            OrderStatusString = "no-show"
+
+	// History entry of a single cancelled occurrence, the order keeps its status
+	OccurrenceCancelledString OrderStatusString = "occurrence-cancelled"
 )
 
 var mapStatusStringToInt = map[OrderStatusString]OrderStatus{
-	PendingString:        Pending,
-	PendingPaymentString: PendingPayment,
-	PaymentSuccessString: PaymentSuccess,
-	ExpiredString:        Expired,
+	PendingString:           Pending,
+	PendingPaymentString:    PendingPayment,
+	PaymentChallengedString: PaymentChallenged,
+	PaymentSuccessString:    PaymentSuccess,
+	ExpiredString:           Expired,
+	CancelledString:         Cancelled,
+	RefundedString:          Refunded,
+	PartiallyRefundedString: PartiallyRefunded,
+	CompletedString:         Completed,
+	NoShowString:            NoShow,
 }
 
 var mapStatusIntToString = map[OrderStatus]OrderStatusString{
-	Pending:        PendingString,
-	PendingPayment: PendingPaymentString,
-	PaymentSuccess: PaymentSuccessString,
-	Expired:        ExpiredString,
+	Pending:           PendingString,
+	PendingPayment:    PendingPaymentString,
+	PaymentChallenged: PaymentChallengedString,
+	PaymentSuccess:    PaymentSuccessString,
+	Expired:           ExpiredString,
+	Cancelled:         CancelledString,
+	Refunded:          RefundedString,
+	PartiallyRefunded: PartiallyRefundedString,
+	Completed:         CompletedString,
+	NoShow:            NoShowString,
 }
 
 func (p OrderStatusString) String() string {
@@ -0,0 +1,8 @@
+package constants
+
+type TimelineSource string
+
+const (
+	OrderTimelineSource   TimelineSource = "order"
+	PaymentTimelineSource TimelineSource = "payment"
+)
@@ -0,0 +1,12 @@
+package constants
+
+type VoucherType string
+
+const (
+	PercentageVoucher VoucherType = "percentage"
+	FixedVoucher      VoucherType = "fixed"
+)
+
+func (v VoucherType) String() string {
+	return string(v)
+}
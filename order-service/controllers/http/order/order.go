@@ -1,9 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	errValidation "order-service/common/error"
 	"order-service/common/response"
+	"order-service/constants"
+	errConstant "order-service/constants/error"
+	errOrder "order-service/constants/error/order"
 	"order-service/domain/dto"
 	"order-service/services"
 
@@ -19,13 +23,32 @@ type IOrderController interface {
 	GetAllWithPagination(ctx *gin.Context)
 	GetByUUID(ctx *gin.Context)
 	GetOrderByUserID(*gin.Context)
+	GetHistory(*gin.Context)
 	Create(*gin.Context)
+	CreateRecurring(*gin.Context)
+	Cancel(*gin.Context)
+	CancelOccurrence(*gin.Context)
+	UpdateStatus(*gin.Context)
+	GetStuckSagas(*gin.Context)
 }
 
 func NewOrderController(service services.IServiceRegistry) IOrderController {
 	return &OrderController{service: service}
 }
 
+// HTTP status of the service error, the other errors are a bad request
+func errorCode(err error) int {
+	switch {
+	case errors.Is(err, errConstant.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, errOrder.ErrOrderNotFound), errors.Is(err, errOrder.ErrOccurrenceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errConstant.ErrIdempotencyKeyConflict), errors.Is(err, errConstant.ErrIdempotencyKeyInProgress):
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
 // Get All Wih Pagination Controller
 func (o *OrderController) GetAllWithPagination(c *gin.Context) {
 	var params dto.OrderRequestParam
@@ -54,10 +77,10 @@ func (o *OrderController) GetAllWithPagination(c *gin.Context) {
 		return
 	}
 
-	result, err := o.service.GetOrder().GetAllWithPagination(c, &params)
+	result, err := o.service.GetOrder().GetAllWithPagination(c.Request.Context(), &params)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
+			Code: errorCode(err),
 			Err:  err,
 			Gin:  c,
 		})
@@ -77,7 +100,7 @@ func (o *OrderController) GetByUUID(c *gin.Context) {
 	result, err := o.service.GetOrder().GetByUUID(c, uuid)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
+			Code: errorCode(err),
 			Err:  err,
 			Gin:  c,
 		})
@@ -96,7 +119,26 @@ func (o *OrderController) GetOrderByUserID(c *gin.Context) {
 	result, err := o.service.GetOrder().GetOrderByUserID(c.Request.Context())
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
+			Code: errorCode(err),
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// Get History Controller
+func (o *OrderController) GetHistory(c *gin.Context) {
+	result, err := o.service.GetOrder().GetHistory(c.Request.Context(), c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: errorCode(err),
 			Err:  err,
 			Gin:  c,
 		})
@@ -140,7 +182,28 @@ func (o *OrderController) Create(c *gin.Context) {
 		return
 	}
 
+	request.IdempotencyKey = c.GetHeader(constants.IdempotencyKey)
 	result, err := o.service.GetOrder().Create(ctx, &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: errorCode(err),
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// Create Recurring Controller
+func (o *OrderController) CreateRecurring(c *gin.Context) {
+	var request dto.RecurringOrderRequest
+	err := c.ShouldBindJSON(&request)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
 			Code: http.StatusBadRequest,
@@ -150,6 +213,131 @@ func (o *OrderController) Create(c *gin.Context) {
 		return
 	}
 
+	validate := validator.New()
+	if err = validate.Struct(request); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := o.service.GetOrder().CreateRecurring(c.Request.Context(), &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: errorCode(err),
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// Cancel Controller
+func (o *OrderController) Cancel(c *gin.Context) {
+	result, err := o.service.GetOrder().Cancel(c.Request.Context(), c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: errorCode(err),
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// Update Status Controller
+func (o *OrderController) UpdateStatus(c *gin.Context) {
+	var request dto.UpdateOrderStatusRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err = validate.Struct(request); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := o.service.GetOrder().UpdateStatus(c.Request.Context(), c.Param("uuid"), &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: errorCode(err),
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// Cancel Occurrence Controller
+func (o *OrderController) CancelOccurrence(c *gin.Context) {
+	result, err := o.service.GetOrder().CancelOccurrence(c.Request.Context(), c.Param("uuid"), c.Param("fieldScheduleID"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: errorCode(err),
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// Get Stuck Sagas Controller
+func (o *OrderController) GetStuckSagas(c *gin.Context) {
+	result, err := o.service.GetOrder().GetStuckSagas(c.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: errorCode(err),
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
 	response.HttpResponse(response.ParamHTTPResp{
 		Code: http.StatusOK,
 		Data: result,
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	controllers "order-service/controllers/http/order"
+	voucherControllers "order-service/controllers/http/voucher"
 	"order-service/services"
 )
 
@@ -11,6 +12,7 @@ type Registry struct {
 
 type IControllerRegistry interface {
 	GetOrder() controllers.IOrderController
+	GetVoucher() voucherControllers.IVoucherController
 }
 
 func NewControllerRegistry(service services.IServiceRegistry) IControllerRegistry {
@@ -20,3 +22,7 @@ func NewControllerRegistry(service services.IServiceRegistry) IControllerRegistr
 func (r *Registry) GetOrder() controllers.IOrderController {
 	return controllers.NewOrderController(r.service)
 }
+
+func (r *Registry) GetVoucher() voucherControllers.IVoucherController {
+	return voucherControllers.NewVoucherController(r.service)
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"net/http"
+	errValidation "order-service/common/error"
+	"order-service/common/response"
+	"order-service/domain/dto"
+	"order-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+)
+
+type VoucherController struct {
+	service services.IServiceRegistry
+}
+
+type IVoucherController interface {
+	GetAll(*gin.Context)
+	Create(*gin.Context)
+}
+
+func NewVoucherController(service services.IServiceRegistry) IVoucherController {
+	return &VoucherController{service: service}
+}
+
+// Get All Controller
+func (v *VoucherController) GetAll(c *gin.Context) {
+	result, err := v.service.GetVoucher().GetAll(c.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// Create Controller
+func (v *VoucherController) Create(c *gin.Context) {
+	var request dto.VoucherRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err = validate.Struct(request); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := v.service.GetVoucher().Create(c.Request.Context(), &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
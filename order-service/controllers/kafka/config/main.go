@@ -1,28 +1,42 @@
 package kafka
 
 import (
+	commonKafka "common/kafka"
 	"order-service/config"
 	"order-service/controllers/kafka"
+	kafkaField "order-service/controllers/kafka/field"
 	kafkaPayment "order-service/controllers/kafka/payment"
+	"time"
 
 	"golang.org/x/exp/slices"
 )
 
 type Kafka struct {
-	consumer *ConsumerGroup
+	consumer *commonKafka.ConsumerGroup
 	kafka    kafka.IKafkaRegistry
 }
 
+// Kafka settings of the service for the consumer group, the dead letter topic and the producer
+func NewConfig() commonKafka.Config {
+	return commonKafka.Config{
+		MaxRetry:   config.Config.Kafka.MaxRetry,
+		Timeout:    time.Duration(config.Config.Kafka.TimeoutInMS) * time.Millisecond,
+		BackOff:    time.Duration(config.Config.Kafka.BackOffTimeInMs) * time.Millisecond,
+		MaxBackOff: time.Duration(config.Config.Kafka.MaxBackOffTimeInMs) * time.Millisecond,
+	}
+}
+
 type IKafka interface {
 	Register()
 }
 
-func NewKafkaConsumer(consumer *ConsumerGroup, kafka kafka.IKafkaRegistry) IKafka {
+func NewKafkaConsumer(consumer *commonKafka.ConsumerGroup, kafka kafka.IKafkaRegistry) IKafka {
 	return &Kafka{consumer: consumer, kafka: kafka}
 }
 
 func (k *Kafka) Register() {
 	k.paymentHandler()
+	k.fieldHandler()
 }
 
 func (k *Kafka) paymentHandler() {
@@ -31,3 +45,9 @@ func (k *Kafka) paymentHandler() {
 		k.consumer.RegisterHandler(kafkaPayment.PaymentTopic, k.kafka.GetPayment().HandlePayment)
 	}
 }
+
+func (k *Kafka) fieldHandler() {
+	if slices.Contains(config.Config.Kafka.Topics, kafkaField.FieldTopic) {
+		k.consumer.RegisterHandler(kafkaField.FieldTopic, k.kafka.GetField().HandleFieldSchedule)
+	}
+}
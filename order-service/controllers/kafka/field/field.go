@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"common/event"
+	"context"
+	"encoding/json"
+	"order-service/domain/dto"
+	"order-service/services"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+const FieldTopic = "field-service-event"
+
+type FieldKafka struct {
+	service services.IServiceRegistry
+}
+
+type IFieldKafka interface {
+	HandleFieldSchedule(context.Context, *sarama.ConsumerMessage) error
+}
+
+func NewFieldKafka(service services.IServiceRegistry) IFieldKafka {
+	return &FieldKafka{service: service}
+}
+
+func (f *FieldKafka) HandleFieldSchedule(ctx context.Context, message *sarama.ConsumerMessage) error {
+	envelope, err := event.Decode(message.Value)
+	if err != nil {
+		logrus.Errorf("failed to decode field schedule event: %v", err)
+		return err
+	}
+
+	if envelope.Version != event.FieldLatestVersion {
+		return event.ErrUnsupportedVersion
+	}
+
+	var payload dto.FieldScheduleEventV1
+	err = json.Unmarshal(envelope.Payload, &payload)
+	if err != nil {
+		logrus.Errorf("failed to unmarshal field schedule event %s: %v", envelope.ID, err)
+		return err
+	}
+
+	err = f.service.GetOrder().HandleFieldScheduleEvent(ctx, envelope.ID, envelope.Type, &payload)
+	if err != nil {
+		logrus.Errorf("failed to handle field schedule event %s: %v", envelope.ID, err)
+		return err
+	}
+	return nil
+}
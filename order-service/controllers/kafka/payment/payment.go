@@ -1,9 +1,10 @@
 package kafka
 
 import (
+	"common/event"
 	"context"
 	"encoding/json"
-	"order-service/common/utils"
+	"errors"
 	"order-service/domain/dto"
 	"order-service/services"
 
@@ -26,21 +27,67 @@ func NewPaymentKafka(service services.IServiceRegistry) IPaymentKafka {
 }
 
 func (p *PaymentKafka) HandlePayment(ctx context.Context, message *sarama.ConsumerMessage) error {
-	defer utils.Recover()
-	var body dto.PaymentContent
-
-	err := json.Unmarshal(message.Value, &body)
+	eventID, data, err := p.decode(message.Value)
 	if err != nil {
-		logrus.Errorf("failes to unmarshal message :%v", err)
+		logrus.Errorf("failed to decode payment event: %v", err)
 		return err
 	}
 
-	data := body.Body.Data
-	err = p.service.GetOrder().HandlePayment(ctx, &data)
+	err = p.service.GetOrder().HandlePayment(ctx, eventID, data)
 	if err != nil {
-		logrus.Errorf("failes to unmarshal message :%v", err)
+		logrus.Errorf("failed to handle payment event %s: %v", eventID, err)
 		return err
 	}
 	logrus.Infof("success handle payment")
 	return nil
 }
+
+// Both versions of the event are accepted, and the message without envelope still in the topic
+func (p *PaymentKafka) decode(value []byte) (string, *dto.PaymentData, error) {
+	envelope, err := event.Decode(value)
+	if errors.Is(err, event.ErrNotEnvelope) {
+		var body dto.PaymentContent
+		err = json.Unmarshal(value, &body)
+		if err != nil {
+			return "", nil, err
+		}
+		return body.Metadata.EventID, &body.Body.Data, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if envelope.Type != event.PaymentStatusChanged {
+		return "", nil, event.ErrUnsupportedVersion
+	}
+
+	switch envelope.Version {
+	case 1:
+		var payload dto.PaymentEventV1
+		err = json.Unmarshal(envelope.Payload, &payload)
+		if err != nil {
+			return "", nil, err
+		}
+		return envelope.ID, &dto.PaymentData{
+			OrderID:   payload.OrderID,
+			PaymentID: payload.PaymentID,
+			Status:    payload.Status,
+			ExpiredAt: payload.ExpiredAt,
+			PaidAt:    payload.PaidAt,
+		}, nil
+	case 2:
+		var payload dto.PaymentEventV2
+		err = json.Unmarshal(envelope.Payload, &payload)
+		if err != nil {
+			return "", nil, err
+		}
+		return envelope.ID, &dto.PaymentData{
+			OrderID:   payload.OrderID,
+			PaymentID: payload.PaymentID,
+			Status:    payload.Status,
+			ExpiredAt: payload.ExpiredAt,
+			PaidAt:    payload.PaidAt,
+			Amount:    &payload.Amount,
+		}, nil
+	}
+	return "", nil, event.ErrUnsupportedVersion
+}
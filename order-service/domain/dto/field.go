@@ -1,5 +1,23 @@
 package dto
 
+import "github.com/google/uuid"
+
 type UpdateFieldScheduleStatusRequest struct {
-	FieldScheduleIDs []string `json:"fieldScheduleIDs"`
+	FieldScheduleIDs []string  `json:"fieldScheduleIDs"`
+	Status           string    `json:"status,omitempty"`
+	OrderID          uuid.UUID `json:"orderID"`
+}
+
+type HoldFieldScheduleRequest struct {
+	FieldScheduleIDs []string   `json:"fieldScheduleIDs"`
+	UserID           *uuid.UUID `json:"userID,omitempty"`
+	OrderID          uuid.UUID  `json:"orderID"`
+}
+
+type FieldScheduleLookupRequest struct {
+	FieldID   string
+	TimeID    string
+	Weekday   int
+	StartDate string
+	EndDate   string
 }
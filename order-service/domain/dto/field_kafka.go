@@ -0,0 +1,11 @@
+package dto
+
+import "github.com/google/uuid"
+
+// Payload of the field.schedule.booked and field.schedule.booking-failed events, version 1.
+// Only the failed booking carries the reason.
+type FieldScheduleEventV1 struct {
+	OrderID          uuid.UUID   `json:"orderID"`
+	FieldScheduleIDs []uuid.UUID `json:"fieldScheduleIDs"`
+	Reason           string      `json:"reason"`
+}
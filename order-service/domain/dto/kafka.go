@@ -5,6 +5,7 @@ type KafkaEvent struct {
 }
 
 type KafkaMetaData struct {
+	EventID   string `json:"eventID"`
 	Sender    string `json:"sender"`
 	SendingAt string `json:"sendingAt"`
 }
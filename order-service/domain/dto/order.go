@@ -8,13 +8,27 @@ import (
 
 type OrderRequest struct {
 	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
+	VoucherCode      string   `json:"voucherCode"`
+	Shares           int      `json:"shares" validate:"omitempty,min=2,max=30"`
+	IdempotencyKey   string   `json:"-"`
 }
 
 type OrderRequestParam struct {
-	Page       int     `form:"page" validate:"required"`
-	Limit      int     `form:"limit" validate:"required"`
-	SortColumn *string `form:"sortColumn"`
-	SortOrder  *string `form:"sortOrder"`
+	Page       int                          `form:"page" validate:"required"`
+	Limit      int                          `form:"limit" validate:"required"`
+	SortColumn *string                      `form:"sortColumn" validate:"omitempty,oneof=code amount status date is_paid paid_at created_at updated_at"`
+	SortOrder  *string                      `form:"sortOrder" validate:"omitempty,oneof=asc desc"`
+	Status     *constants.OrderStatusString `form:"status" validate:"omitempty,oneof=pending pending-payment payment-challenged payment-success expired cancelled refunded partially-refunded completed no-show"`
+	StartDate  time.Time                    `form:"startDate" time_format:"2006-01-02"`
+	EndDate    time.Time                    `form:"endDate" time_format:"2006-01-02"`
+	FieldID    *string                      `form:"fieldID" validate:"omitempty,uuid"`
+	UserID     *string                      `form:"userID" validate:"omitempty,uuid"`
+	IsPaid     *bool                        `form:"isPaid"`
+	Code       *string                      `form:"code"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status constants.OrderStatusString `json:"status" validate:"required,oneof=completed no-show cancelled"`
 }
 
 type OrderResponse struct {
@@ -22,8 +36,11 @@ type OrderResponse struct {
 	Code        string                      `json:"code"`
 	UserName    string                      `json:"userName"`
 	Amount      float64                     `json:"amount"`
+	Discount    float64                     `json:"discount,omitempty"`
+	VoucherCode *string                     `json:"voucherCode,omitempty"`
 	Status      constants.OrderStatusString `json:"status"`
 	PaymentLink string                      `json:"paymentLink,omitempty"`
+	Shares      []OrderPaymentShareResponse `json:"shares,omitempty"`
 	OrderDate   time.Time                   `json:"orderDate"`
 	CreatedAt   time.Time                   `json:"createdAt"`
 	UpdatedAt   time.Time                   `json:"updatedAt"`
@@ -37,3 +54,13 @@ type OrderByUserIDResponse struct {
 	PaymentLink string                      `json:"paymentLink"`
 	InvoiceLink *string                     `json:"invoiceLink"`
 }
+
+// Payload of the order events, version 1
+type OrderEventV1 struct {
+	OrderID          uuid.UUID                   `json:"orderID"`
+	Code             string                      `json:"code"`
+	UserID           uuid.UUID                   `json:"userID"`
+	Amount           float64                     `json:"amount"`
+	Status           constants.OrderStatusString `json:"status"`
+	FieldScheduleIDs []uuid.UUID                 `json:"fieldScheduleIDs"`
+}
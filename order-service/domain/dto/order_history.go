@@ -1,8 +1,29 @@
 package dto
 
-import "order-service/constants"
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type OrderHistoryRequest struct {
 	OrderID uint
 	Status  constants.OrderStatusString
+	Actor   constants.OrderActor
+}
+
+type OrderTimelineResponse struct {
+	UUID     uuid.UUID                   `json:"uuid"`
+	Code     string                      `json:"code"`
+	Status   constants.OrderStatusString `json:"status"`
+	Timeline []OrderTimelineItem         `json:"timeline"`
+}
+
+type OrderTimelineItem struct {
+	Source    constants.TimelineSource `json:"source"`
+	Status    string                   `json:"status"`
+	Actor     constants.OrderActor     `json:"actor,omitempty"`
+	Share     int                      `json:"share,omitempty"`
+	CreatedAt time.Time                `json:"createdAt"`
 }
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderPaymentShareResponse struct {
+	UUID        uuid.UUID                          `json:"uuid"`
+	Sequence    int                                `json:"sequence"`
+	Amount      float64                            `json:"amount"`
+	Status      constants.PaymentShareStatusString `json:"status"`
+	PaymentLink string                             `json:"paymentLink,omitempty"`
+	PaidAt      *time.Time                         `json:"paidAt,omitempty"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderSagaResponse struct {
+	UUID        uuid.UUID                   `json:"uuid"`
+	OrderID     uuid.UUID                   `json:"orderID"`
+	OrderCode   string                      `json:"orderCode"`
+	OrderStatus constants.OrderStatusString `json:"orderStatus"`
+	Step        constants.SagaStep          `json:"step"`
+	Status      constants.SagaStatusString  `json:"status"`
+	LastError   *string                     `json:"lastError,omitempty"`
+	Steps       []OrderSagaStepResponse     `json:"steps"`
+	CreatedAt   time.Time                   `json:"createdAt"`
+	UpdatedAt   time.Time                   `json:"updatedAt"`
+}
+
+type OrderSagaStepResponse struct {
+	Step      constants.SagaStep       `json:"step"`
+	Status    constants.SagaStepStatus `json:"status"`
+	Error     *string                  `json:"error,omitempty"`
+	CreatedAt time.Time                `json:"createdAt"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/google/uuid"
+
+type RecurringOrderRequest struct {
+	FieldID     string `json:"fieldID" validate:"required,uuid"`
+	TimeID      string `json:"timeID" validate:"required,uuid"`
+	Weekday     *int   `json:"weekday" validate:"required,min=0,max=6"`
+	StartDate   string `json:"startDate" validate:"required"`
+	EndDate     string `json:"endDate" validate:"required"`
+	VoucherCode string `json:"voucherCode"`
+}
+
+type RecurringOrderResponse struct {
+	SeriesID    uuid.UUID            `json:"seriesID"`
+	Order       OrderResponse        `json:"order"`
+	Reserved    []OccurrenceResponse `json:"reserved"`
+	Unavailable []OccurrenceResponse `json:"unavailable"`
+}
+
+type OccurrenceResponse struct {
+	FieldScheduleID *uuid.UUID `json:"fieldScheduleID,omitempty"`
+	Date            string     `json:"date"`
+	Time            string     `json:"time,omitempty"`
+	Status          string     `json:"status"`
+}
@@ -12,6 +12,7 @@ type PaymentRequest struct {
 	Description    string         `json:"description"`
 	CustomerDetail CustomerDetail `json:"customerDetail"`
 	ItemDetails    []ItemDetails  `json:"itemDetails"`
+	IdempotencyKey string         `json:"-"`
 }
 
 type CustomerDetail struct {
@@ -26,3 +27,16 @@ type ItemDetails struct {
 	Amount   float64   `json:"amount"`
 	Quantity int       `json:"quantity"`
 }
+
+// Refund or cancellation of a payment, relayed to payment-service by the outbox
+type PaymentOutboxRequest struct {
+	PaymentID uuid.UUID `json:"paymentID"`
+	Amount    *float64  `json:"amount,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+type PaymentRefundRequest struct {
+	Amount    *float64 `json:"amount,omitempty"`
+	Reason    string   `json:"reason"`
+	RefundKey string   `json:"refundKey,omitempty"`
+}
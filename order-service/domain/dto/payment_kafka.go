@@ -12,10 +12,31 @@ type PaymentData struct {
 	Status    constants.PaymentStatusString `json:"status"`
 	ExpiredAt *time.Time                    `json:"expiredAt"`
 	PaidAt    *time.Time                    `json:"paidAt"`
+	Amount    *float64                      `json:"amount"`
 }
 
+// Message sent before the event envelope was introduced
 type PaymentContent struct {
 	Event    KafkaEvent             `json:"event"`
 	Metadata KafkaMetaData          `json:"metadata"`
 	Body     KafkaBody[PaymentData] `json:"body"`
 }
+
+// Payload of the payment.status.changed event, version 1
+type PaymentEventV1 struct {
+	OrderID   uuid.UUID                     `json:"orderID"`
+	PaymentID uuid.UUID                     `json:"paymentID"`
+	Status    constants.PaymentStatusString `json:"status"`
+	ExpiredAt *time.Time                    `json:"expiredAt"`
+	PaidAt    *time.Time                    `json:"paidAt"`
+}
+
+// Version 2 adds the amount of the payment
+type PaymentEventV2 struct {
+	OrderID   uuid.UUID                     `json:"orderID"`
+	PaymentID uuid.UUID                     `json:"paymentID"`
+	Status    constants.PaymentStatusString `json:"status"`
+	Amount    float64                       `json:"amount"`
+	ExpiredAt *time.Time                    `json:"expiredAt"`
+	PaidAt    *time.Time                    `json:"paidAt"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VoucherRequest struct {
+	Code              string                `json:"code" validate:"required,max=30"`
+	Type              constants.VoucherType `json:"type" validate:"required,oneof=percentage fixed"`
+	Value             float64               `json:"value" validate:"required,gt=0"`
+	MaxDiscount       *float64              `json:"maxDiscount" validate:"omitempty,gt=0"`
+	MinSpend          float64               `json:"minSpend" validate:"min=0"`
+	StartAt           time.Time             `json:"startAt" validate:"required"`
+	EndAt             time.Time             `json:"endAt" validate:"required"`
+	UsageLimit        int                   `json:"usageLimit" validate:"min=0"`
+	UsageLimitPerUser int                   `json:"usageLimitPerUser" validate:"min=0"`
+	FieldIDs          []string              `json:"fieldIDs" validate:"omitempty,dive,uuid"`
+	Weekdays          []int                 `json:"weekdays" validate:"omitempty,dive,min=0,max=6"`
+}
+
+type VoucherResponse struct {
+	UUID              uuid.UUID             `json:"uuid"`
+	Code              string                `json:"code"`
+	Type              constants.VoucherType `json:"type"`
+	Value             float64               `json:"value"`
+	MaxDiscount       *float64              `json:"maxDiscount,omitempty"`
+	MinSpend          float64               `json:"minSpend"`
+	StartAt           time.Time             `json:"startAt"`
+	EndAt             time.Time             `json:"endAt"`
+	UsageLimit        int                   `json:"usageLimit"`
+	UsageLimitPerUser int                   `json:"usageLimitPerUser"`
+	UsedCount         int                   `json:"usedCount"`
+	FieldIDs          []string              `json:"fieldIDs,omitempty"`
+	Weekdays          []int                 `json:"weekdays,omitempty"`
+	CreatedAt         *time.Time            `json:"createdAt"`
+	UpdatedAt         *time.Time            `json:"updatedAt"`
+}
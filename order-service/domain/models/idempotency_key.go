@@ -0,0 +1,20 @@
+package models
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IdempotencyKey struct {
+	ID          uint                        `gorm:"primaryKey;autoIncrement"`
+	Key         string                      `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	UserID      uuid.UUID                   `gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	RequestHash string                      `gorm:"type:varchar(64);not null"`
+	Response    *string                     `gorm:"type:jsonb"`
+	Status      constants.IdempotencyStatus `gorm:"type:int;not null"`
+	LockedUntil *time.Time                  `gorm:"type:timestamp"`
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
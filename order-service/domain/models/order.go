@@ -8,16 +8,21 @@ import (
 )
 
 type Order struct {
-	ID        uint                  `gorm:"primaryKey;autoIncrement"`
-	UUID      uuid.UUID             `gorm:"type:uuid;not null"`
-	Code      string                `gorm:"type:varchar(30);not null"`
-	UserID    uuid.UUID             `gorm:"type:uuid;not null"`
-	PaymentID uuid.UUID             `gorm:"type:uuid;not null"`
-	Amount    float64               `gorm:"type:decimal(10,2);not null"`
-	Status    constants.OrderStatus `gorm:"type:int;not null"`
-	Date      time.Time             `gorm:"type:timestamp;not null"`
-	IsPaid    bool                  `gorm:"type:boolean;not null"`
-	PaidAt    *time.Time            `gorm:"type:timestamp"`
-	CreatedAt *time.Time
-	UpdatedAt *time.Time
+	ID          uint                  `gorm:"primaryKey;autoIncrement"`
+	UUID        uuid.UUID             `gorm:"type:uuid;not null"`
+	Code        string                `gorm:"type:varchar(30);not null"`
+	UserID      uuid.UUID             `gorm:"type:uuid;not null"`
+	PaymentID   uuid.UUID             `gorm:"type:uuid;not null"`
+	Amount      float64               `gorm:"type:decimal(10,2);not null"`
+	Discount    float64               `gorm:"type:decimal(10,2);not null;default:0"`
+	VoucherID   *uint                 `gorm:"type:bigint"`
+	VoucherCode *string               `gorm:"type:varchar(30)"`
+	ShareCount  int                   `gorm:"type:int;not null;default:0"`
+	Status      constants.OrderStatus `gorm:"type:int;not null"`
+	Date        time.Time             `gorm:"type:timestamp;not null"`
+	IsPaid      bool                  `gorm:"type:boolean;not null"`
+	PaidAt      *time.Time            `gorm:"type:timestamp"`
+	ExpiredAt   *time.Time            `gorm:"type:timestamp"`
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
 }
@@ -6,9 +6,12 @@ import (
 )
 
 type OrderField struct {
-	ID              uint      `gorm:"primaryKey;autoIncrement"`
-	OrderID         uint      `gorm:"type:bigint;not null"`
-	FieldScheduleID uuid.UUID `gorm:"type:uuid;not null"`
+	ID              uint       `gorm:"primaryKey;autoIncrement"`
+	OrderID         uint       `gorm:"type:bigint;not null"`
+	FieldScheduleID uuid.UUID  `gorm:"type:uuid;not null"`
+	FieldID         uuid.UUID  `gorm:"type:uuid"`
+	Amount          float64    `gorm:"type:decimal(10,2);not null;default:0"`
+	CancelledAt     *time.Time `gorm:"type:timestamp"`
 	CreatedAt       *time.Time
 	UpdatedAt       *time.Time
 }
@@ -9,6 +9,7 @@ type OrderHistory struct {
 	ID        uint                        `gorm:"primaryKey;autoIncrement"`
 	OrderID   uint                        `gorm:"type:bigint;not null"`
 	Status    constants.OrderStatusString `gorm:"type:varchar(30);not null"`
+	Actor     constants.OrderActor        `gorm:"type:varchar(20);not null;default:'system'"`
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
 }
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderOutbox struct {
+	ID            uint                      `gorm:"primaryKey;autoIncrement"`
+	UUID          uuid.UUID                 `gorm:"type:uuid;not null"`
+	OrderID       uint                      `gorm:"type:bigint;not null"`
+	EventType     constants.OutboxEventType `gorm:"type:varchar(50);not null"`
+	Payload       string                    `gorm:"type:jsonb;not null"`
+	Status        constants.OutboxStatus    `gorm:"type:int;not null"`
+	Attempts      int                       `gorm:"type:int;not null;default:0"`
+	NextAttemptAt time.Time                 `gorm:"type:timestamp;not null"`
+	LastError     *string                   `gorm:"type:text"`
+	ProcessedAt   *time.Time                `gorm:"type:timestamp"`
+	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Part of a split order paid by one team member, its UUID is the order ID of its own payment
+type OrderPaymentShare struct {
+	ID        uint                         `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID                    `gorm:"type:uuid;not null;uniqueIndex"`
+	OrderID   uint                         `gorm:"type:bigint;not null;index"`
+	Sequence  int                          `gorm:"type:int;not null"`
+	PaymentID *uuid.UUID                   `gorm:"type:uuid"`
+	Amount    float64                      `gorm:"type:decimal(10,2);not null"`
+	Status    constants.PaymentShareStatus `gorm:"type:int;not null"`
+	PaidAt    *time.Time                   `gorm:"type:timestamp"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
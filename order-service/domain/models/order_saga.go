@@ -0,0 +1,30 @@
+package models
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Booking saga of an order, Step is the step it is running or compensating
+type OrderSaga struct {
+	ID        uint                 `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID            `gorm:"type:uuid;not null"`
+	OrderID   uint                 `gorm:"type:bigint;not null;uniqueIndex"`
+	Step      constants.SagaStep   `gorm:"type:varchar(30);not null"`
+	Status    constants.SagaStatus `gorm:"type:int;not null"`
+	LastError *string              `gorm:"type:text"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+// Outcome of a saga step, the log of the saga
+type OrderSagaStep struct {
+	ID        uint                     `gorm:"primaryKey;autoIncrement"`
+	SagaID    uint                     `gorm:"type:bigint;not null;index"`
+	Step      constants.SagaStep       `gorm:"type:varchar(30);not null"`
+	Status    constants.SagaStepStatus `gorm:"type:varchar(20);not null"`
+	Error     *string                  `gorm:"type:text"`
+	CreatedAt *time.Time
+}
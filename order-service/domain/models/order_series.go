@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Recurring weekly booking of the same field and time slot, reserved by a single order
+type OrderSeries struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	OrderID   uint      `gorm:"type:bigint;not null"`
+	FieldID   uuid.UUID `gorm:"type:uuid;not null"`
+	TimeID    uuid.UUID `gorm:"type:uuid;not null"`
+	Weekday   int       `gorm:"type:int;not null"`
+	StartDate time.Time `gorm:"type:date;not null"`
+	EndDate   time.Time `gorm:"type:date;not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type ProcessedEvent struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	EventID     string    `gorm:"type:varchar(100);not null;uniqueIndex"`
+	EventName   string    `gorm:"type:varchar(50);not null"`
+	ProcessedAt time.Time `gorm:"type:timestamp;not null"`
+}
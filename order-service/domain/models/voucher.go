@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Voucher struct {
+	ID                uint                  `gorm:"primaryKey;autoIncrement"`
+	UUID              uuid.UUID             `gorm:"type:uuid;not null"`
+	Code              string                `gorm:"type:varchar(30);not null;uniqueIndex"`
+	Type              constants.VoucherType `gorm:"type:varchar(20);not null"`
+	Value             float64               `gorm:"type:decimal(10,2);not null"`
+	MaxDiscount       *float64              `gorm:"type:decimal(10,2)"`
+	MinSpend          float64               `gorm:"type:decimal(10,2);not null;default:0"`
+	StartAt           time.Time             `gorm:"type:timestamp;not null"`
+	EndAt             time.Time             `gorm:"type:timestamp;not null"`
+	UsageLimit        int                   `gorm:"type:int;not null;default:0"`
+	UsageLimitPerUser int                   `gorm:"type:int;not null;default:0"`
+	UsedCount         int                   `gorm:"type:int;not null;default:0"`
+	FieldIDs          *string               `gorm:"type:jsonb"`
+	Weekdays          *string               `gorm:"type:jsonb"`
+	CreatedAt         *time.Time
+	UpdatedAt         *time.Time
+}
+
+type VoucherUsage struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	VoucherID uint      `gorm:"type:bigint;not null;index"`
+	OrderID   uint      `gorm:"type:bigint;not null;uniqueIndex"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+// Fields the voucher is restricted to, empty when it applies to every field
+func (v *Voucher) FieldIDList() []string {
+	var fieldIDs []string
+	if v.FieldIDs != nil {
+		_ = json.Unmarshal([]byte(*v.FieldIDs), &fieldIDs)
+	}
+	return fieldIDs
+}
+
+// Weekdays the voucher is restricted to, empty when it applies to every day
+func (v *Voucher) WeekdayList() []int {
+	var weekdays []int
+	if v.Weekdays != nil {
+		_ = json.Unmarshal([]byte(*v.Weekdays), &weekdays)
+	}
+	return weekdays
+}
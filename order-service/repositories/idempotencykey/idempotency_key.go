@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "order-service/common/error"
+	"order-service/constants"
+	errConstant "order-service/constants/error"
+	"order-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+type IIdempotencyKeyRepository interface {
+	FindByKey(context.Context, uuid.UUID, string) (*models.IdempotencyKey, error)
+	Reserve(context.Context, *models.IdempotencyKey) (bool, error)
+	Reclaim(context.Context, uint, time.Time, time.Time) (bool, error)
+	Complete(context.Context, uint, string) error
+	Delete(context.Context, uint) error
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) IIdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db: db}
+}
+
+func (i *IdempotencyKeyRepository) FindByKey(ctx context.Context, userID uuid.UUID, key string) (*models.IdempotencyKey, error) {
+	var idempotencyKey models.IdempotencyKey
+	err := i.db.WithContext(ctx).Where("user_id = ? AND key = ?", userID, key).First(&idempotencyKey).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &idempotencyKey, nil
+}
+
+// Reserve the key, returns false when the key is already taken by another request
+func (i *IdempotencyKeyRepository) Reserve(ctx context.Context, param *models.IdempotencyKey) (bool, error) {
+	idempotencyKey := models.IdempotencyKey{
+		Key:         param.Key,
+		UserID:      param.UserID,
+		RequestHash: param.RequestHash,
+		Status:      constants.IdempotencyProcessing,
+		LockedUntil: param.LockedUntil,
+	}
+
+	result := i.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&idempotencyKey)
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	param.ID = idempotencyKey.ID
+	return result.RowsAffected > 0, nil
+}
+
+// Take over the key of a request that did not finish before its lock expired, false when it is still locked
+func (i *IdempotencyKeyRepository) Reclaim(ctx context.Context, id uint, now time.Time, lockedUntil time.Time) (bool, error) {
+	result := i.db.WithContext(ctx).
+		Model(&models.IdempotencyKey{}).
+		Where("id = ? AND status = ?", id, constants.IdempotencyProcessing).
+		Where("locked_until IS NULL OR locked_until < ?", now).
+		Update("locked_until", lockedUntil)
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (i *IdempotencyKeyRepository) Complete(ctx context.Context, id uint, response string) error {
+	err := i.db.WithContext(ctx).Model(&models.IdempotencyKey{}).Where("id = ?", id).Updates(&models.IdempotencyKey{
+		Response: &response,
+		Status:   constants.IdempotencyCompleted,
+	}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (i *IdempotencyKeyRepository) Delete(ctx context.Context, id uint) error {
+	err := i.db.WithContext(ctx).Where("id = ?", id).Delete(&models.IdempotencyKey{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	errWrap "order-service/common/error"
+	"order-service/constants"
 	errConstant "order-service/constants/error"
 	errOrder "order-service/constants/error/order"
 	"order-service/domain/dto"
@@ -13,6 +14,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
 	"gorm.io/gorm"
 )
 
@@ -23,39 +25,76 @@ type OrderRepository struct {
 type IOrderRepository interface {
 	FindAllWithPagination(context.Context, *dto.OrderRequestParam) ([]models.Order, int64, error)
 	FindByUUID(context.Context, string) (*models.Order, error)
+	FindByID(context.Context, uint) (*models.Order, error)
 	FindByUserID(context.Context, string) ([]models.Order, error)
+	FindAllExpiredPending(context.Context, time.Time) ([]models.Order, error)
 	Create(context.Context, *gorm.DB, *models.Order) (*models.Order, error)
 	Update(context.Context, *gorm.DB, *models.Order, uuid.UUID) error
+	UpdateStatus(context.Context, *gorm.DB, *models.Order, uuid.UUID, constants.OrderStatus) error
 }
 
 func NewOrderRepository(db *gorm.DB) IOrderRepository {
 	return &OrderRepository{db: db}
 }
 
+// Columns the listing can be sorted by
+var sortableColumns = []string{"code", "amount", "status", "date", "is_paid", "paid_at", "created_at", "updated_at"}
+
+// Apply the listing filters to the query
+func (o *OrderRepository) filter(query *gorm.DB, param *dto.OrderRequestParam) *gorm.DB {
+	if param.Status != nil {
+		query = query.Where("status = ?", param.Status.GetStatusInt())
+	}
+	if !param.StartDate.IsZero() {
+		query = query.Where("date >= ?", param.StartDate)
+	}
+	if !param.EndDate.IsZero() {
+		// The end date is inclusive
+		query = query.Where("date < ?", param.EndDate.AddDate(0, 0, 1))
+	}
+	if param.UserID != nil {
+		query = query.Where("user_id = ?", *param.UserID)
+	}
+	if param.IsPaid != nil {
+		query = query.Where("is_paid = ?", *param.IsPaid)
+	}
+	if param.Code != nil && *param.Code != "" {
+		query = query.Where("code ILIKE ?", "%"+*param.Code+"%")
+	}
+	if param.FieldID != nil {
+		query = query.Where("id IN (?)", o.db.Model(&models.OrderField{}).
+			Select("order_id").
+			Where("field_id = ?", *param.FieldID))
+	}
+	return query
+}
+
 // Find All With Pagination
 func (o *OrderRepository) FindAllWithPagination(ctx context.Context, param *dto.OrderRequestParam) ([]models.Order, int64, error) {
 	var (
 		orders []models.Order
-		sort   string
+		sort   = "created_at desc"
 		total  int64
 	)
 
-	if param.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *param.SortColumn, *param.SortOrder)
-	} else {
-		sort = "created_at desc"
+	if param.SortColumn != nil && slices.Contains(sortableColumns, *param.SortColumn) {
+		sortOrder := "asc"
+		if param.SortOrder != nil && *param.SortOrder == "desc" {
+			sortOrder = "desc"
+		}
+		sort = fmt.Sprintf("%s %s", *param.SortColumn, sortOrder)
 	}
 
 	limit := param.Limit
 	offset := (param.Page - 1) * limit
 	// Get the data
-	err := o.db.WithContext(ctx).Limit(limit).Offset(offset).Order(sort).Find(&orders).Error
+	err := o.filter(o.db.WithContext(ctx), param).Limit(limit).Offset(offset).Order(sort).Find(&orders).Error
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
 	// Get the count data
-	err = o.db.WithContext(ctx).Model(&models.Order{}).Count(&total).Error
+	err = o.filter(o.db.WithContext(ctx).Model(&models.Order{}), param).Count(&total).Error
 	if err != nil {
 		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
 	}
@@ -77,6 +116,20 @@ func (o *OrderRepository) FindByUUID(ctx context.Context, uuid string) (*models.
 	return &order, nil
 }
 
+// Find by ID
+func (o *OrderRepository) FindByID(ctx context.Context, id uint) (*models.Order, error) {
+	var order models.Order
+	err := o.db.WithContext(ctx).Where("id = ?", id).First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errOrder.ErrOrderNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &order, nil
+}
+
 // Find by User ID
 func (o *OrderRepository) FindByUserID(ctx context.Context, userID string) ([]models.Order, error) {
 	var orders []models.Order
@@ -91,6 +144,23 @@ func (o *OrderRepository) FindByUserID(ctx context.Context, userID string) ([]mo
 	return orders, nil
 }
 
+// Find All Pending Orders whose payment has expired
+func (o *OrderRepository) FindAllExpiredPending(ctx context.Context, now time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	err := o.db.
+		WithContext(ctx).
+		Where("status IN ?", []constants.OrderStatus{constants.Pending, constants.PendingPayment}).
+		Where("expired_at IS NOT NULL").
+		Where("expired_at < ?", now).
+		Find(&orders).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return orders, nil
+}
+
 // Create order code (increment)
 func (o *OrderRepository) incrementCode(ctx context.Context) (*string, error) {
 	var (
@@ -111,9 +181,9 @@ func (o *OrderRepository) incrementCode(ctx context.Context) (*string, error) {
 		orderCode := order.Code
 		splitOrderName, _ := strconv.Atoi(orderCode[4:9])
 		code := splitOrderName + 1
-		result = fmt.Sprint("ORD-%5d-%s", code, today)
+		result = fmt.Sprintf("ORD-%05d-%s", code, today)
 	} else {
-		result = fmt.Sprint("ORD-%5d-%s", 1, today)
+		result = fmt.Sprintf("ORD-%05d-%s", 1, today)
 	}
 	// (ORD-00001-2010002) sample output
 	return &result, nil
@@ -126,14 +196,25 @@ func (o *OrderRepository) Create(ctx context.Context, tx *gorm.DB, param *models
 		return nil, err
 	}
 
+	// The UUID is given when the field schedules are held for the order before it is created
+	orderUUID := param.UUID
+	if orderUUID == uuid.Nil {
+		orderUUID = uuid.New()
+	}
+
 	order := &models.Order{
-		UUID:   uuid.New(),
-		Code:   *code,
-		UserID: param.UserID,
-		Amount: param.Amount,
-		Date:   param.Date,
-		Status: param.Status,
-		IsPaid: param.IsPaid,
+		UUID:        orderUUID,
+		Code:        *code,
+		UserID:      param.UserID,
+		Amount:      param.Amount,
+		Discount:    param.Discount,
+		VoucherID:   param.VoucherID,
+		VoucherCode: param.VoucherCode,
+		ShareCount:  param.ShareCount,
+		Date:        param.Date,
+		Status:      param.Status,
+		IsPaid:      param.IsPaid,
+		ExpiredAt:   param.ExpiredAt,
 	}
 
 	err = tx.WithContext(ctx).Create(order).Error
@@ -145,9 +226,33 @@ func (o *OrderRepository) Create(ctx context.Context, tx *gorm.DB, param *models
 
 // Update
 func (o *OrderRepository) Update(ctx context.Context, tx *gorm.DB, request *models.Order, uuid uuid.UUID) error {
-	err := tx.WithContext(ctx).Model(&models.Order{}).Where("uuid = $1").Updates(request).Error
+	err := tx.WithContext(ctx).Model(&models.Order{}).Where("uuid = ?", uuid).Updates(request).Error
 	if err != nil {
 		return errWrap.WrapError(errConstant.ErrSQLError)
 	}
 	return nil
 }
+
+// Update Status, only applied when the order is still in the expected status
+func (o *OrderRepository) UpdateStatus(
+	ctx context.Context,
+	tx *gorm.DB,
+	request *models.Order,
+	uuid uuid.UUID,
+	from constants.OrderStatus,
+) error {
+	result := tx.WithContext(ctx).
+		Model(&models.Order{}).
+		Where("uuid = ?", uuid).
+		Where("status = ?", from).
+		Updates(request)
+	if result.Error != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	// Another writer has changed the status in the meantime
+	if result.RowsAffected == 0 {
+		return errWrap.WrapError(errOrder.ErrInvalidStatusTransition)
+	}
+	return nil
+}
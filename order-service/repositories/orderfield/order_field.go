@@ -2,9 +2,12 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	errWrap "order-service/common/error"
 	errConstant "order-service/constants/error"
+	errOrder "order-service/constants/error/order"
 	"order-service/domain/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -15,7 +18,9 @@ type OrderFieldRepository struct {
 
 type IOrderFieldRepository interface {
 	FindByOrderID(context.Context, uint) ([]models.OrderField, error)
+	FindByOrderIDAndFieldScheduleID(context.Context, uint, string) (*models.OrderField, error)
 	Create(context.Context, *gorm.DB, []models.OrderField) error
+	Cancel(context.Context, *gorm.DB, uint) error
 }
 
 func NewOrderFieldRepository(db *gorm.DB) IOrderFieldRepository {
@@ -31,6 +36,27 @@ func (o *OrderFieldRepository) FindByOrderID(ctx context.Context, orderID uint)
 	return orderFields, nil
 }
 
+func (o *OrderFieldRepository) FindByOrderIDAndFieldScheduleID(
+	ctx context.Context,
+	orderID uint,
+	fieldScheduleID string,
+) (*models.OrderField, error) {
+	var orderField models.OrderField
+	err := o.db.
+		WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Where("field_schedule_id = ?", fieldScheduleID).
+		First(&orderField).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errOrder.ErrOccurrenceNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &orderField, nil
+}
+
 func (o *OrderFieldRepository) Create(ctx context.Context, tx *gorm.DB, request []models.OrderField) error {
 	err := tx.WithContext(ctx).Create(&request).Error
 	if err != nil {
@@ -38,3 +64,12 @@ func (o *OrderFieldRepository) Create(ctx context.Context, tx *gorm.DB, request
 	}
 	return nil
 }
+
+// Cancel a single occurrence of the order
+func (o *OrderFieldRepository) Cancel(ctx context.Context, tx *gorm.DB, id uint) error {
+	err := tx.WithContext(ctx).Model(&models.OrderField{}).Where("id = ?", id).Update("cancelled_at", time.Now()).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ type OrderHistoryRepository struct {
 }
 
 type IOrderHistoryRespository interface {
+	FindByOrderID(context.Context, uint) ([]models.OrderHistory, error)
 	Create(context.Context, *gorm.DB, *dto.OrderHistoryRequest) error
 }
 
@@ -22,10 +23,22 @@ func NewOrderHistoRepository(db *gorm.DB) IOrderHistoryRespository {
 	return &OrderHistoryRepository{db: db}
 }
 
+// Find by Order ID, the oldest first
+func (o *OrderHistoryRepository) FindByOrderID(ctx context.Context, orderID uint) ([]models.OrderHistory, error) {
+	var histories []models.OrderHistory
+	err := o.db.WithContext(ctx).Where("order_id = ?", orderID).Order("created_at asc, id asc").Find(&histories).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return histories, nil
+}
+
 func (o *OrderHistoryRepository) Create(ctx context.Context, tx *gorm.DB, param *dto.OrderHistoryRequest) error {
 	orderHistory := &models.OrderHistory{
 		OrderID: param.OrderID,
 		Status:  param.Status,
+		Actor:   param.Actor,
 	}
 
 	err := tx.WithContext(ctx).Create(&orderHistory).Error
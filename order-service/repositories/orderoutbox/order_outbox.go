@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	errWrap "order-service/common/error"
+	"order-service/constants"
+	errConstant "order-service/constants/error"
+	"order-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OrderOutboxRepository struct {
+	db *gorm.DB
+}
+
+type IOrderOutboxRepository interface {
+	FindAllDue(context.Context, time.Time, int) ([]models.OrderOutbox, error)
+	Create(context.Context, *gorm.DB, *models.OrderOutbox) (*models.OrderOutbox, error)
+	Claim(context.Context, *models.OrderOutbox, time.Time, time.Time) (bool, error)
+	Update(context.Context, *models.OrderOutbox) error
+}
+
+func NewOrderOutboxRepository(db *gorm.DB) IOrderOutboxRepository {
+	return &OrderOutboxRepository{db: db}
+}
+
+// Find the pending entries that are ready to be relayed,
+// an order event waits until the previous events of the order are relayed
+func (o *OrderOutboxRepository) FindAllDue(ctx context.Context, now time.Time, limit int) ([]models.OrderOutbox, error) {
+	var outboxes []models.OrderOutbox
+	err := o.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", constants.OutboxPending, now).
+		Where(`event_type <> ? OR NOT EXISTS (
+			SELECT 1 FROM order_outboxes previous
+			WHERE previous.order_id = order_outboxes.order_id
+			AND previous.event_type = order_outboxes.event_type
+			AND previous.status = ?
+			AND previous.id < order_outboxes.id
+		)`, constants.OrderEvent, constants.OutboxPending).
+		Order("id asc").
+		Limit(limit).
+		Find(&outboxes).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return outboxes, nil
+}
+
+func (o *OrderOutboxRepository) Create(ctx context.Context, tx *gorm.DB, param *models.OrderOutbox) (*models.OrderOutbox, error) {
+	outbox := models.OrderOutbox{
+		UUID:          uuid.New(),
+		OrderID:       param.OrderID,
+		EventType:     param.EventType,
+		Payload:       param.Payload,
+		Status:        constants.OutboxPending,
+		NextAttemptAt: param.NextAttemptAt,
+	}
+
+	err := tx.WithContext(ctx).Create(&outbox).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &outbox, nil
+}
+
+// Claim the due entry until the lease ends, false when another relay has claimed it first
+func (o *OrderOutboxRepository) Claim(
+	ctx context.Context,
+	param *models.OrderOutbox,
+	now time.Time,
+	leaseUntil time.Time,
+) (bool, error) {
+	result := o.db.WithContext(ctx).
+		Model(&models.OrderOutbox{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", param.ID, constants.OutboxPending, now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	param.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+func (o *OrderOutboxRepository) Update(ctx context.Context, param *models.OrderOutbox) error {
+	err := o.db.WithContext(ctx).
+		Model(&models.OrderOutbox{}).
+		Where("id = ?", param.ID).
+		Select("status", "attempts", "next_attempt_at", "last_error", "processed_at").
+		Updates(param).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
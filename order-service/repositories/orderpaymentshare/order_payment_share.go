@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "order-service/common/error"
+	"order-service/constants"
+	errConstant "order-service/constants/error"
+	"order-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OrderPaymentShareRepository struct {
+	db *gorm.DB
+}
+
+type IOrderPaymentShareRepository interface {
+	FindByUUID(context.Context, string) (*models.OrderPaymentShare, error)
+	FindByOrderID(context.Context, uint) ([]models.OrderPaymentShare, error)
+	CountUnpaidByOrderID(context.Context, uint) (int64, error)
+	Create(context.Context, *gorm.DB, []models.OrderPaymentShare) ([]models.OrderPaymentShare, error)
+	UpdatePaymentID(context.Context, uint, uuid.UUID) error
+	Update(context.Context, *models.OrderPaymentShare) error
+}
+
+func NewOrderPaymentShareRepository(db *gorm.DB) IOrderPaymentShareRepository {
+	return &OrderPaymentShareRepository{db: db}
+}
+
+// Find by UUID, returns nil when the payment does not belong to a share
+func (o *OrderPaymentShareRepository) FindByUUID(ctx context.Context, uuid string) (*models.OrderPaymentShare, error) {
+	var share models.OrderPaymentShare
+	err := o.db.WithContext(ctx).Where("uuid = ?", uuid).First(&share).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &share, nil
+}
+
+func (o *OrderPaymentShareRepository) FindByOrderID(ctx context.Context, orderID uint) ([]models.OrderPaymentShare, error) {
+	var shares []models.OrderPaymentShare
+	err := o.db.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("sequence asc").
+		Find(&shares).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return shares, nil
+}
+
+func (o *OrderPaymentShareRepository) CountUnpaidByOrderID(ctx context.Context, orderID uint) (int64, error) {
+	var total int64
+	err := o.db.WithContext(ctx).
+		Model(&models.OrderPaymentShare{}).
+		Where("order_id = ? AND status <> ?", orderID, constants.SharePaid).
+		Count(&total).Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return total, nil
+}
+
+func (o *OrderPaymentShareRepository) Create(
+	ctx context.Context,
+	tx *gorm.DB,
+	param []models.OrderPaymentShare,
+) ([]models.OrderPaymentShare, error) {
+	shares := make([]models.OrderPaymentShare, 0, len(param))
+	for _, item := range param {
+		shares = append(shares, models.OrderPaymentShare{
+			UUID:     uuid.New(),
+			OrderID:  item.OrderID,
+			Sequence: item.Sequence,
+			Amount:   item.Amount,
+			Status:   constants.SharePending,
+		})
+	}
+
+	err := tx.WithContext(ctx).Create(&shares).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return shares, nil
+}
+
+// Attach the payment created by the relay, the status is left to the payment events
+func (o *OrderPaymentShareRepository) UpdatePaymentID(ctx context.Context, id uint, paymentID uuid.UUID) error {
+	err := o.db.WithContext(ctx).
+		Model(&models.OrderPaymentShare{}).
+		Where("id = ?", id).
+		Update("payment_id", paymentID).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (o *OrderPaymentShareRepository) Update(ctx context.Context, param *models.OrderPaymentShare) error {
+	err := o.db.WithContext(ctx).
+		Model(&models.OrderPaymentShare{}).
+		Where("id = ?", param.ID).
+		Select("payment_id", "status", "paid_at").
+		Updates(param).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "order-service/common/error"
+	"order-service/constants"
+	errConstant "order-service/constants/error"
+	"order-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OrderSagaRepository struct {
+	db *gorm.DB
+}
+
+type IOrderSagaRepository interface {
+	FindByOrderID(context.Context, uint) (*models.OrderSaga, error)
+	FindAllStuck(context.Context, time.Time) ([]models.OrderSaga, error)
+	FindStepsBySagaID(context.Context, uint) ([]models.OrderSagaStep, error)
+	Create(context.Context, *gorm.DB, *models.OrderSaga) (*models.OrderSaga, error)
+	CreateStep(context.Context, *gorm.DB, *models.OrderSagaStep) error
+	Update(context.Context, *gorm.DB, *models.OrderSaga) error
+}
+
+func NewOrderSagaRepository(db *gorm.DB) IOrderSagaRepository {
+	return &OrderSagaRepository{db: db}
+}
+
+// Find by Order ID, returns nil for an order created before the saga was tracked
+func (o *OrderSagaRepository) FindByOrderID(ctx context.Context, orderID uint) (*models.OrderSaga, error) {
+	var saga models.OrderSaga
+	err := o.db.WithContext(ctx).Where("order_id = ?", orderID).First(&saga).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &saga, nil
+}
+
+// Find the sagas which failed, or have not moved since before the time, the oldest first
+func (o *OrderSagaRepository) FindAllStuck(ctx context.Context, before time.Time) ([]models.OrderSaga, error) {
+	var sagas []models.OrderSaga
+	err := o.db.WithContext(ctx).
+		Where("status = ? OR (status IN ? AND updated_at < ?)",
+			constants.SagaFailed, []constants.SagaStatus{constants.SagaRunning, constants.SagaCompensating}, before).
+		Order("updated_at asc").
+		Find(&sagas).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return sagas, nil
+}
+
+// Find the steps of the saga, the oldest first
+func (o *OrderSagaRepository) FindStepsBySagaID(ctx context.Context, sagaID uint) ([]models.OrderSagaStep, error) {
+	var steps []models.OrderSagaStep
+	err := o.db.WithContext(ctx).Where("saga_id = ?", sagaID).Order("id asc").Find(&steps).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return steps, nil
+}
+
+func (o *OrderSagaRepository) Create(ctx context.Context, tx *gorm.DB, param *models.OrderSaga) (*models.OrderSaga, error) {
+	saga := models.OrderSaga{
+		UUID:    uuid.New(),
+		OrderID: param.OrderID,
+		Step:    param.Step,
+		Status:  param.Status,
+	}
+
+	err := tx.WithContext(ctx).Create(&saga).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &saga, nil
+}
+
+func (o *OrderSagaRepository) CreateStep(ctx context.Context, tx *gorm.DB, param *models.OrderSagaStep) error {
+	err := tx.WithContext(ctx).Create(param).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (o *OrderSagaRepository) Update(ctx context.Context, tx *gorm.DB, param *models.OrderSaga) error {
+	err := tx.WithContext(ctx).
+		Model(&models.OrderSaga{}).
+		Where("id = ?", param.ID).
+		Select("step", "status", "last_error").
+		Updates(param).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
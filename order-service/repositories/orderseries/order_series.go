@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	errWrap "order-service/common/error"
+	errConstant "order-service/constants/error"
+	"order-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OrderSeriesRepository struct {
+	db *gorm.DB
+}
+
+type IOrderSeriesRepository interface {
+	Create(context.Context, *gorm.DB, *models.OrderSeries) (*models.OrderSeries, error)
+}
+
+func NewOrderSeriesRepository(db *gorm.DB) IOrderSeriesRepository {
+	return &OrderSeriesRepository{db: db}
+}
+
+func (o *OrderSeriesRepository) Create(ctx context.Context, tx *gorm.DB, param *models.OrderSeries) (*models.OrderSeries, error) {
+	series := &models.OrderSeries{
+		UUID:      uuid.New(),
+		OrderID:   param.OrderID,
+		FieldID:   param.FieldID,
+		TimeID:    param.TimeID,
+		Weekday:   param.Weekday,
+		StartDate: param.StartDate,
+		EndDate:   param.EndDate,
+	}
+
+	err := tx.WithContext(ctx).Create(series).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return series, nil
+}
@@ -1,9 +1,16 @@
 package repositories
 
 import (
+	idempotencyKeyRepo "order-service/repositories/idempotencykey"
 	orderRepo "order-service/repositories/order"
 	orderFieldRepo "order-service/repositories/orderfield"
 	orderHistoryRepo "order-service/repositories/orderhistory"
+	orderOutboxRepo "order-service/repositories/orderoutbox"
+	orderPaymentShareRepo "order-service/repositories/orderpaymentshare"
+	orderSagaRepo "order-service/repositories/ordersaga"
+	orderSeriesRepo "order-service/repositories/orderseries"
+	processedEventRepo "order-service/repositories/processedevent"
+	voucherRepo "order-service/repositories/voucher"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +23,13 @@ type IRepositoryRegistry interface {
 	GetOrder() orderRepo.IOrderRepository
 	GetOrderField() orderFieldRepo.IOrderFieldRepository
 	GetOrderHistory() orderHistoryRepo.IOrderHistoryRespository
+	GetOrderOutbox() orderOutboxRepo.IOrderOutboxRepository
+	GetOrderSaga() orderSagaRepo.IOrderSagaRepository
+	GetOrderSeries() orderSeriesRepo.IOrderSeriesRepository
+	GetOrderPaymentShare() orderPaymentShareRepo.IOrderPaymentShareRepository
+	GetIdempotencyKey() idempotencyKeyRepo.IIdempotencyKeyRepository
+	GetProcessedEvent() processedEventRepo.IProcessedEventRepository
+	GetVoucher() voucherRepo.IVoucherRepository
 	GetTx() *gorm.DB
 }
 
@@ -35,6 +49,34 @@ func (r *Registry) GetOrderHistory() orderHistoryRepo.IOrderHistoryRespository {
 	return orderHistoryRepo.NewOrderHistoRepository(r.db)
 }
 
+func (r *Registry) GetOrderOutbox() orderOutboxRepo.IOrderOutboxRepository {
+	return orderOutboxRepo.NewOrderOutboxRepository(r.db)
+}
+
+func (r *Registry) GetOrderSaga() orderSagaRepo.IOrderSagaRepository {
+	return orderSagaRepo.NewOrderSagaRepository(r.db)
+}
+
+func (r *Registry) GetOrderSeries() orderSeriesRepo.IOrderSeriesRepository {
+	return orderSeriesRepo.NewOrderSeriesRepository(r.db)
+}
+
+func (r *Registry) GetOrderPaymentShare() orderPaymentShareRepo.IOrderPaymentShareRepository {
+	return orderPaymentShareRepo.NewOrderPaymentShareRepository(r.db)
+}
+
+func (r *Registry) GetIdempotencyKey() idempotencyKeyRepo.IIdempotencyKeyRepository {
+	return idempotencyKeyRepo.NewIdempotencyKeyRepository(r.db)
+}
+
+func (r *Registry) GetProcessedEvent() processedEventRepo.IProcessedEventRepository {
+	return processedEventRepo.NewProcessedEventRepository(r.db)
+}
+
+func (r *Registry) GetVoucher() voucherRepo.IVoucherRepository {
+	return voucherRepo.NewVoucherRepository(r.db)
+}
+
 func (r *Registry) GetTx() *gorm.DB {
 	return r.db
 }
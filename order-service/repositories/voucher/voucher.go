@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "order-service/common/error"
+	errConstant "order-service/constants/error"
+	errVoucher "order-service/constants/error/voucher"
+	"order-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type VoucherRepository struct {
+	db *gorm.DB
+}
+
+type IVoucherRepository interface {
+	FindAll(context.Context) ([]models.Voucher, error)
+	FindByCode(context.Context, string) (*models.Voucher, error)
+	CountUsageByUserID(context.Context, uint, uuid.UUID) (int64, error)
+	Create(context.Context, *models.Voucher) (*models.Voucher, error)
+	Use(context.Context, *gorm.DB, *models.VoucherUsage) error
+	Release(context.Context, *gorm.DB, uint) error
+}
+
+func NewVoucherRepository(db *gorm.DB) IVoucherRepository {
+	return &VoucherRepository{db: db}
+}
+
+func (v *VoucherRepository) FindAll(ctx context.Context) ([]models.Voucher, error) {
+	var vouchers []models.Voucher
+	err := v.db.WithContext(ctx).Order("created_at desc").Find(&vouchers).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return vouchers, nil
+}
+
+func (v *VoucherRepository) FindByCode(ctx context.Context, code string) (*models.Voucher, error) {
+	var voucher models.Voucher
+	err := v.db.WithContext(ctx).Where("code = ?", code).First(&voucher).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errVoucher.ErrVoucherNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &voucher, nil
+}
+
+func (v *VoucherRepository) CountUsageByUserID(ctx context.Context, voucherID uint, userID uuid.UUID) (int64, error) {
+	var total int64
+	err := v.db.
+		WithContext(ctx).
+		Model(&models.VoucherUsage{}).
+		Where("voucher_id = ?", voucherID).
+		Where("user_id = ?", userID).
+		Count(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return total, nil
+}
+
+func (v *VoucherRepository) Create(ctx context.Context, param *models.Voucher) (*models.Voucher, error) {
+	param.UUID = uuid.New()
+	err := v.db.WithContext(ctx).Create(param).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return param, nil
+}
+
+// Use the voucher for the order, the usage limit is checked in the same statement to avoid overselling.
+// The update locks the voucher until the transaction ends, so the usages of the user are counted after it.
+func (v *VoucherRepository) Use(ctx context.Context, tx *gorm.DB, usage *models.VoucherUsage) error {
+	result := tx.
+		WithContext(ctx).
+		Model(&models.Voucher{}).
+		Where("id = ?", usage.VoucherID).
+		Where("usage_limit = 0 OR used_count < usage_limit").
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	if result.RowsAffected == 0 {
+		return errWrap.WrapError(errVoucher.ErrVoucherUsageLimitReached)
+	}
+
+	var voucher models.Voucher
+	err := tx.WithContext(ctx).Select("usage_limit_per_user").Where("id = ?", usage.VoucherID).First(&voucher).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	if voucher.UsageLimitPerUser > 0 {
+		var used int64
+		err = tx.
+			WithContext(ctx).
+			Model(&models.VoucherUsage{}).
+			Where("voucher_id = ?", usage.VoucherID).
+			Where("user_id = ?", usage.UserID).
+			Count(&used).
+			Error
+		if err != nil {
+			return errWrap.WrapError(errConstant.ErrSQLError)
+		}
+		if used >= int64(voucher.UsageLimitPerUser) {
+			return errWrap.WrapError(errVoucher.ErrVoucherUsageLimitReached)
+		}
+	}
+
+	err = tx.WithContext(ctx).Create(usage).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+// Release the voucher used by the order, nothing happens when the order has no voucher
+func (v *VoucherRepository) Release(ctx context.Context, tx *gorm.DB, orderID uint) error {
+	var usage models.VoucherUsage
+	err := tx.WithContext(ctx).Where("order_id = ?", orderID).First(&usage).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	err = tx.WithContext(ctx).Delete(&usage).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	err = tx.
+		WithContext(ctx).
+		Model(&models.Voucher{}).
+		Where("id = ?", usage.VoucherID).
+		Where("used_count > 0").
+		Update("used_count", gorm.Expr("used_count - 1")).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
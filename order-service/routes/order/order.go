@@ -35,8 +35,19 @@ func (o *OrderRoute) Run() {
 
 	group.GET("/:uuid", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, o.clients), o.GetOrder().GetByUUID)
 
+	group.GET("/:uuid/history", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, o.clients), o.GetOrder().GetHistory)
+
 	group.GET("/user", middlewares.CheckRole([]string{constants.Customer}, o.clients), o.GetOrder().GetOrderByUserID)
 
+	group.GET("/saga/stuck", middlewares.CheckRole([]string{constants.Admin}, o.clients), o.GetOrder().GetStuckSagas)
+
 	group.POST("", middlewares.CheckRole([]string{constants.Customer}, o.clients), o.GetOrder().Create)
 
+	group.POST("/recurring", middlewares.CheckRole([]string{constants.Customer}, o.clients), o.GetOrder().CreateRecurring)
+
+	group.POST("/:uuid/cancel", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, o.clients), o.GetOrder().Cancel)
+
+	group.POST("/:uuid/occurrences/:fieldScheduleID/cancel", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, o.clients), o.GetOrder().CancelOccurrence)
+
+	group.PATCH("/:uuid/status", middlewares.CheckRole([]string{constants.Admin}, o.clients), o.GetOrder().UpdateStatus)
 }
@@ -4,6 +4,7 @@ import (
 	"order-service/clients"
 	controllers "order-service/controllers/http"
 	routes "order-service/routes/order"
+	voucherRoutes "order-service/routes/voucher"
 
 	"github.com/gin-gonic/gin"
 )
@@ -28,8 +29,13 @@ func NewRouteRegistry(group *gin.RouterGroup, controller controllers.IController
 
 func (r *Registry) Serve() {
 	r.orderRoute().Run()
+	r.voucherRoute().Run()
 }
 
 func (r *Registry) orderRoute() routes.IOrderRoute {
 	return routes.NewOrderRoute(r.group, r.controller, r.client)
 }
+
+func (r *Registry) voucherRoute() voucherRoutes.IVoucherRoute {
+	return voucherRoutes.NewVoucherRoute(r.group, r.controller, r.client)
+}
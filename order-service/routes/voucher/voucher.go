@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"order-service/clients"
+	"order-service/constants"
+	controllers "order-service/controllers/http"
+	"order-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VoucherRoute struct {
+	controllers.IControllerRegistry
+	clients clients.IClientRegistry
+	group   *gin.RouterGroup
+}
+
+type IVoucherRoute interface {
+	Run()
+}
+
+func NewVoucherRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry) IVoucherRoute {
+	return &VoucherRoute{
+		IControllerRegistry: controller,
+		clients:             client,
+		group:               group,
+	}
+}
+
+func (v *VoucherRoute) Run() {
+	group := v.group.Group("/voucher")
+	group.Use(middlewares.Authenticate())
+
+	group.GET("", middlewares.CheckRole([]string{constants.Admin}, v.clients), v.GetVoucher().GetAll)
+
+	group.POST("", middlewares.CheckRole([]string{constants.Admin}, v.clients), v.GetVoucher().Create)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"common/event"
+	"context"
+	"order-service/config"
+	"order-service/constants"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Field schedules still held or booked by the order, cancelled occurrences are left out
+func (o *OrderService) findFieldScheduleIDs(ctx context.Context, orderID uint) ([]uuid.UUID, error) {
+	orderFieldSchedules, err := o.repository.GetOrderField().FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldScheduleIDs := make([]uuid.UUID, 0, len(orderFieldSchedules))
+	for _, item := range orderFieldSchedules {
+		if item.CancelledAt != nil {
+			continue
+		}
+		fieldScheduleIDs = append(fieldScheduleIDs, item.FieldScheduleID)
+	}
+	return fieldScheduleIDs, nil
+}
+
+// Store the order event in the outbox within the transaction of the order change
+func (o *OrderService) enqueueEvent(
+	ctx context.Context,
+	tx *gorm.DB,
+	eventType string,
+	order *models.Order,
+	status constants.OrderStatus,
+	fieldScheduleIDs []uuid.UUID,
+) error {
+	message, err := event.New(eventType, event.OrderLatestVersion, "order-service", order.UUID.String(), dto.OrderEventV1{
+		OrderID:          order.UUID,
+		Code:             order.Code,
+		UserID:           order.UserID,
+		Amount:           order.Amount,
+		Status:           status.GetStatusString(),
+		FieldScheduleIDs: fieldScheduleIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = o.repository.GetOrderOutbox().Create(ctx, tx, &models.OrderOutbox{
+		OrderID:       order.ID,
+		EventType:     constants.OrderEvent,
+		Payload:       string(message),
+		NextAttemptAt: time.Now(),
+	})
+	return err
+}
+
+// Publish the order event of the outbox entry, keyed by the order so its events stay in order
+func (o *OrderService) publishOutbox(ctx context.Context, outbox *models.OrderOutbox) error {
+	envelope, err := event.Decode([]byte(outbox.Payload))
+	if err != nil {
+		return o.failOutbox(ctx, outbox, err)
+	}
+
+	topic := config.Config.Kafka.EventTopic
+	if topic == "" {
+		topic = constants.OrderEventTopic
+	}
+	err = o.producer.ProduceMessage(topic, envelope.CorrelationID, []byte(outbox.Payload))
+	if err != nil {
+		failErr := o.failOutbox(ctx, outbox, err)
+		if failErr != nil {
+			return failErr
+		}
+		return err
+	}
+
+	now := time.Now()
+	outbox.Status = constants.OutboxProcessed
+	outbox.ProcessedAt = &now
+	outbox.LastError = nil
+	return o.repository.GetOrderOutbox().Update(ctx, outbox)
+}
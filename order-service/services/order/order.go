@@ -1,39 +1,61 @@
 package services
 
 import (
+	"common/event"
+	"common/kafka"
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"order-service/clients"
 	clientField "order-service/clients/field"
 	clientPayment "order-service/clients/payment"
 	clientUser "order-service/clients/user"
 	"order-service/common/utils"
+	"order-service/config"
 	"order-service/constants"
+	errConstant "order-service/constants/error"
 	errOrder "order-service/constants/error/order"
 	"order-service/domain/dto"
 	"order-service/domain/models"
 	"order-service/repositories"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type OrderService struct {
 	repository repositories.IRepositoryRegistry
 	client     clients.IClientRegistry
+	producer   kafka.IProducer
 }
 
 type IOrderService interface {
 	GetAllWithPagination(context.Context, *dto.OrderRequestParam) (*utils.PaginationResult, error)
 	GetByUUID(context.Context, string) (*dto.OrderResponse, error)
 	GetOrderByUserID(context.Context) ([]dto.OrderByUserIDResponse, error)
+	GetHistory(context.Context, string) (*dto.OrderTimelineResponse, error)
 	Create(context.Context, *dto.OrderRequest) (*dto.OrderResponse, error)
-	HandlePayment(context.Context, *dto.PaymentData) error
+	CreateRecurring(context.Context, *dto.RecurringOrderRequest) (*dto.RecurringOrderResponse, error)
+	Cancel(context.Context, string) (*dto.OrderResponse, error)
+	CancelOccurrence(context.Context, string, string) (*dto.OrderResponse, error)
+	UpdateStatus(context.Context, string, *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error)
+	HandlePayment(context.Context, string, *dto.PaymentData) error
+	ExpireOrders(context.Context) error
+	RelayOutbox(context.Context) error
+	HandleFieldScheduleEvent(context.Context, string, string, *dto.FieldScheduleEventV1) error
+	GetStuckSagas(context.Context) ([]dto.OrderSagaResponse, error)
 }
 
-func NewOrderService(repository repositories.IRepositoryRegistry, client clients.IClientRegistry) IOrderService {
-	return &OrderService{repository: repository, client: client}
+func NewOrderService(
+	repository repositories.IRepositoryRegistry,
+	client clients.IClientRegistry,
+	producer kafka.IProducer,
+) IOrderService {
+	return &OrderService{repository: repository, client: client, producer: producer}
 }
 
 // Get All With Pagination
@@ -42,26 +64,41 @@ func (o *OrderService) GetAllWithPagination(
 	ctx context.Context,
 	param *dto.OrderRequestParam,
 ) (*utils.PaginationResult, error) {
+	// Customer only sees their own orders
+	user := ctx.Value(constants.User).(*clientUser.UserData)
+	if user.Role == constants.Customer {
+		userID := user.UUID.String()
+		param.UserID = &userID
+	}
+
 	orders, total, err := o.repository.GetOrder().FindAllWithPagination(ctx, param)
 	if err != nil {
 		return nil, err
 	}
 
+	// One lookup for all users of the page
+	userIDs := make([]uuid.UUID, 0, len(orders))
+	for _, order := range orders {
+		userIDs = append(userIDs, order.UserID)
+	}
+	userNames, err := o.client.GetUser().GetUserNamesByUUIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	orderResults := make([]dto.OrderResponse, 0, len(orders))
 	for _, order := range orders {
-		user, err := o.client.GetUser().GetUserByUUID(ctx, order.UserID)
-		if err != nil {
-			return nil, err
-		}
 		orderResults = append(orderResults, dto.OrderResponse{
-			UUID:      order.UUID,
-			Code:      order.Code,
-			UserName:  user.Name,
-			Amount:    order.Amount,
-			Status:    order.Status.GetStatusString(),
-			OrderDate: order.Date,
-			CreatedAt: *order.CreatedAt,
-			UpdatedAt: *order.UpdatedAt,
+			UUID:        order.UUID,
+			Code:        order.Code,
+			UserName:    userNames[order.UserID],
+			Amount:      order.Amount,
+			Discount:    order.Discount,
+			VoucherCode: order.VoucherCode,
+			Status:      order.Status.GetStatusString(),
+			OrderDate:   order.Date,
+			CreatedAt:   *order.CreatedAt,
+			UpdatedAt:   *order.UpdatedAt,
 		})
 	}
 
@@ -93,15 +130,23 @@ func (o *OrderService) GetByUUID(ctx context.Context, uuid string) (*dto.OrderRe
 		return nil, err
 	}
 
+	shares, err := o.getShares(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
 	response := dto.OrderResponse{
-		UUID:      order.UUID,
-		Code:      order.Code,
-		UserName:  user.Name,
-		Amount:    order.Amount,
-		Status:    order.Status.GetStatusString(),
-		OrderDate: order.Date,
-		CreatedAt: *order.CreatedAt,
-		UpdatedAt: *order.UpdatedAt,
+		UUID:        order.UUID,
+		Code:        order.Code,
+		UserName:    user.Name,
+		Amount:      order.Amount,
+		Discount:    order.Discount,
+		VoucherCode: order.VoucherCode,
+		Shares:      shares,
+		Status:      order.Status.GetStatusString(),
+		OrderDate:   order.Date,
+		CreatedAt:   *order.CreatedAt,
+		UpdatedAt:   *order.UpdatedAt,
 	}
 	return &response, nil
 }
@@ -118,13 +163,28 @@ func (o *OrderService) GetOrderByUserID(ctx context.Context) ([]dto.OrderByUserI
 		return nil, err
 	}
 
-	orderLists := make([]dto.OrderByUserIDResponse, 0, len(order))
+	// One lookup for all payments, the relay may not have created some of them yet
+	paymentIDs := make([]uuid.UUID, 0, len(order))
 	for _, item := range order {
-		// Get payment link first
-		payment, err := o.client.GetPayment().GetPaymentByUUID(ctx, item.PaymentID)
+		if item.PaymentID != uuid.Nil {
+			paymentIDs = append(paymentIDs, item.PaymentID)
+		}
+	}
+
+	payments := make(map[uuid.UUID]*clientPayment.PaymentData)
+	if len(paymentIDs) > 0 {
+		payments, err = o.client.GetPayment().GetPaymentsByUUIDs(ctx, paymentIDs)
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	orderLists := make([]dto.OrderByUserIDResponse, 0, len(order))
+	for _, item := range order {
+		var payment clientPayment.PaymentData
+		if paymentData, ok := payments[item.PaymentID]; ok {
+			payment = *paymentData
+		}
 
 		orderLists = append(orderLists, dto.OrderByUserIDResponse{
 			Code:        item.Code,
@@ -138,50 +198,294 @@ func (o *OrderService) GetOrderByUserID(ctx context.Context) ([]dto.OrderByUserI
 	return orderLists, nil
 }
 
+// Get History, the order status timeline merged with the payment history
+func (o *OrderService) GetHistory(ctx context.Context, orderUUID string) (*dto.OrderTimelineResponse, error) {
+	user := ctx.Value(constants.User).(*clientUser.UserData)
+	order, err := o.repository.GetOrder().FindByUUID(ctx, orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == constants.Customer && order.UserID != user.UUID {
+		return nil, errConstant.ErrForbidden
+	}
+
+	histories, err := o.repository.GetOrderHistory().FindByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := make([]dto.OrderTimelineItem, 0, len(histories))
+	for _, history := range histories {
+		timeline = append(timeline, dto.OrderTimelineItem{
+			Source:    constants.OrderTimelineSource,
+			Status:    history.Status.String(),
+			Actor:     history.Actor,
+			CreatedAt: timeOrZero(history.CreatedAt),
+		})
+	}
+
+	// The payment link may not be created yet
+	if order.PaymentID != uuid.Nil {
+		timeline, err = o.appendPaymentTimeline(ctx, timeline, order.PaymentID, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A split order has one payment per share instead
+	if order.ShareCount > 0 {
+		shares, err := o.repository.GetOrderPaymentShare().FindByOrderID(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, share := range shares {
+			if share.PaymentID == nil {
+				continue
+			}
+			timeline, err = o.appendPaymentTimeline(ctx, timeline, *share.PaymentID, share.Sequence)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].CreatedAt.Before(timeline[j].CreatedAt)
+	})
+
+	return &dto.OrderTimelineResponse{
+		UUID:     order.UUID,
+		Code:     order.Code,
+		Status:   order.Status.GetStatusString(),
+		Timeline: timeline,
+	}, nil
+}
+
+// Add the history of the payment to the timeline, the share is 0 for the payment of the whole order
+func (o *OrderService) appendPaymentTimeline(
+	ctx context.Context,
+	timeline []dto.OrderTimelineItem,
+	paymentID uuid.UUID,
+	share int,
+) ([]dto.OrderTimelineItem, error) {
+	paymentHistories, err := o.client.GetPayment().GetPaymentHistory(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, history := range paymentHistories {
+		timeline = append(timeline, dto.OrderTimelineItem{
+			Source:    constants.PaymentTimelineSource,
+			Status:    history.Status,
+			Share:     share,
+			CreatedAt: timeOrZero(history.CreatedAt),
+		})
+	}
+	return timeline, nil
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
 // Create
 func (o *OrderService) Create(ctx context.Context, request *dto.OrderRequest) (*dto.OrderResponse, error) {
+	if request.IdempotencyKey == "" {
+		return o.create(ctx, request)
+	}
+
+	var (
+		user     = ctx.Value(constants.User).(*clientUser.UserData)
+		response dto.OrderResponse
+	)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	lockSeconds := config.Config.IdempotencyLockInSeconds
+	if lockSeconds <= 0 {
+		lockSeconds = 300
+	}
+	now := time.Now()
+	lockedUntil := now.Add(time.Duration(lockSeconds) * time.Second)
+	idempotencyKey := &models.IdempotencyKey{
+		Key:         request.IdempotencyKey,
+		UserID:      user.UUID,
+		RequestHash: utils.GenerateSHA256(string(body)),
+		LockedUntil: &lockedUntil,
+	}
+	reserved, err := o.repository.GetIdempotencyKey().Reserve(ctx, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replay, return the original response
+	if !reserved {
+		existing, err := o.repository.GetIdempotencyKey().FindByKey(ctx, user.UUID, request.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, errConstant.ErrIdempotencyKeyInProgress
+		}
+		if existing.RequestHash != idempotencyKey.RequestHash {
+			return nil, errConstant.ErrIdempotencyKeyConflict
+		}
+
+		if existing.Status == constants.IdempotencyCompleted && existing.Response != nil {
+			err = json.Unmarshal([]byte(*existing.Response), &response)
+			if err != nil {
+				return nil, err
+			}
+			return &response, nil
+		}
+
+		// The request that held the key never finished, it is retried once the lock expires
+		reclaimed, err := o.repository.GetIdempotencyKey().Reclaim(ctx, existing.ID, now, lockedUntil)
+		if err != nil {
+			return nil, err
+		}
+		if !reclaimed {
+			return nil, errConstant.ErrIdempotencyKeyInProgress
+		}
+		idempotencyKey.ID = existing.ID
+	}
+
+	result, err := o.create(ctx, request)
+	if err != nil {
+		// Free the key so the client can retry the failed request
+		deleteErr := o.repository.GetIdempotencyKey().Delete(ctx, idempotencyKey.ID)
+		if deleteErr != nil {
+			logrus.Errorf("failed to release idempotency key %s: %v", request.IdempotencyKey, deleteErr)
+		}
+		return nil, err
+	}
+
+	body, err = json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.repository.GetIdempotencyKey().Complete(ctx, idempotencyKey.ID, string(body))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (o *OrderService) create(ctx context.Context, request *dto.OrderRequest) (*dto.OrderResponse, error) {
+	fields := make([]clientField.FieldData, 0, len(request.FieldScheduleIDs))
+	for _, fieldID := range request.FieldScheduleIDs {
+		field, err := o.client.GetField().GetFieldByUUID(ctx, uuid.MustParse(fieldID))
+		if err != nil {
+			return nil, err
+		}
+
+		// Check if the field is already booked, a held schedule may be offered to the user from the waitlist
+		if field.Status == constants.BookedStatus.String() {
+			return nil, errOrder.ErrFiledAlreadyBooked
+		}
+		fields = append(fields, *field)
+	}
+
+	return o.checkout(ctx, fields, nil, request.VoucherCode, request.Shares)
+}
+
+// Hold the field schedules and create the order with its payment outbox, the series is saved for a recurring order.
+// An order with shares is split into one payment per team member.
+func (o *OrderService) checkout(
+	ctx context.Context,
+	fields []clientField.FieldData,
+	series *models.OrderSeries,
+	voucherCode string,
+	shareCount int,
+) (*dto.OrderResponse, error) {
 	var (
 		order               *models.Order
 		txErr, err          error
 		user                = ctx.Value(constants.User).(*clientUser.UserData)
-		field               *clientField.FieldData
-		paymentResponse     *clientPayment.PaymentData
-		orderFieldSchedules = make([]models.OrderField, 0, len(request.FieldScheduleIDs))
+		outboxes            []*models.OrderOutbox
+		shares              []models.OrderPaymentShare
+		shareAmounts        []float64
+		fieldScheduleIDs    = make([]string, 0, len(fields))
+		orderFieldSchedules = make([]models.OrderField, 0, len(fields))
 		totalAmount         float64
+		voucher             *models.Voucher
+		voucherID           *uint
+		voucherCodeApplied  *string
+		discount            float64
 	)
 
-	for _, fieldID := range request.FieldScheduleIDs {
-		uuidParsed := uuid.MustParse(fieldID)
-		field, err = o.client.GetField().GetFieldByUUID(ctx, uuidParsed)
+	for _, field := range fields {
+		fieldScheduleIDs = append(fieldScheduleIDs, field.UUID.String())
+		totalAmount += field.PricePerHour
+	}
+
+	if voucherCode != "" {
+		voucher, discount, err = o.applyVoucher(ctx, user, voucherCode, fields, totalAmount)
 		if err != nil {
 			return nil, err
 		}
+		voucherID = &voucher.ID
+		voucherCodeApplied = &voucher.Code
+	}
 
-		// Check if the field is already booked
-		totalAmount += field.PricePerHour
-		if field.Status == constants.BookedStatus.String() {
-			return nil, errOrder.ErrFiledAlreadyBooked
+	if shareCount > 1 {
+		shareAmounts, err = splitShareAmounts(totalAmount-discount, shareCount)
+		if err != nil {
+			return nil, err
 		}
+	} else {
+		shareCount = 0
+	}
+
+	// Hold the schedules for the order until the payment expires, so no other order can take them
+	holdTTL := config.Config.OrderHoldTTLInMinutes
+	if holdTTL <= 0 {
+		holdTTL = 60
+	}
+	orderUUID := uuid.New()
+	expiredAt := time.Now().Add(time.Duration(holdTTL) * time.Minute)
+	err = o.client.GetField().HoldFieldSchedules(&dto.HoldFieldScheduleRequest{
+		FieldScheduleIDs: fieldScheduleIDs,
+		UserID:           &user.UUID,
+		OrderID:          orderUUID,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Transaction to Create Order
 	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
 		order, txErr = o.repository.GetOrder().Create(ctx, tx, &models.Order{
-			UserID: user.UUID,
-			Amount: totalAmount,
-			Date:   time.Now(),
-			Status: constants.Pending,
-			IsPaid: false,
+			UUID:        orderUUID,
+			UserID:      user.UUID,
+			Amount:      totalAmount - discount,
+			Discount:    discount,
+			VoucherID:   voucherID,
+			VoucherCode: voucherCodeApplied,
+			ShareCount:  shareCount,
+			Date:        time.Now(),
+			Status:      constants.PendingPayment,
+			IsPaid:      false,
+			ExpiredAt:   &expiredAt,
 		})
 		if txErr != nil {
 			return txErr
 		}
 
-		for _, fieldID := range request.FieldScheduleIDs {
-			uuidParsed := uuid.MustParse(fieldID)
+		for _, field := range fields {
 			orderFieldSchedules = append(orderFieldSchedules, models.OrderField{
 				OrderID:         order.ID,
-				FieldScheduleID: uuidParsed,
+				FieldScheduleID: field.UUID,
+				FieldID:         field.FieldID,
+				Amount:          field.PricePerHour,
 			})
 		}
 
@@ -191,150 +495,856 @@ func (o *OrderService) Create(ctx context.Context, request *dto.OrderRequest) (*
 			return txErr
 		}
 
+		if voucher != nil {
+			txErr = o.repository.GetVoucher().Use(ctx, tx, &models.VoucherUsage{
+				VoucherID: voucher.ID,
+				OrderID:   order.ID,
+				UserID:    user.UUID,
+			})
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		if series != nil {
+			series.OrderID = order.ID
+			created, txErr := o.repository.GetOrderSeries().Create(ctx, tx, series)
+			if txErr != nil {
+				return txErr
+			}
+			series.UUID = created.UUID
+		}
+
 		txErr = o.repository.GetOrderHistory().Create(ctx, tx, &dto.OrderHistoryRequest{
-			Status:  constants.Pending.GetStatusString(),
+			Status:  constants.PendingPayment.GetStatusString(),
+			Actor:   constants.CustomerActor,
 			OrderID: order.ID,
 		})
 		if txErr != nil {
 			return txErr
 		}
 
-		// Create payment link to payment-service (midtrans)
-		expiredAt := time.Now().Add(1 + time.Hour)
-		description := fmt.Sprintf("Pembayaran Sewa %s", field.FieldName)
-		paymentResponse, txErr = o.client.GetPayment().CreatePaymentLink(ctx, &dto.PaymentRequest{
-			OrderID:     order.UUID,
-			ExpiredAt:   expiredAt,
-			Amount:      totalAmount,
-			Description: description,
-			CustomerDetail: dto.CustomerDetail{
-				Name:  user.Username,
-				Email: user.Email,
-				Phone: user.PhoneNumber,
-			},
-			ItemDetails: []dto.ItemDetails{
-				{
-					ID:       uuid.New(),
-					Name:     description,
-					Amount:   totalAmount,
-					Quantity: 1,
-				},
-			},
-		})
+		createdScheduleIDs := make([]uuid.UUID, 0, len(fields))
+		for _, field := range fields {
+			createdScheduleIDs = append(createdScheduleIDs, field.UUID)
+		}
+		txErr = o.enqueueEvent(ctx, tx, event.OrderCreated, order, constants.PendingPayment, createdScheduleIDs)
 		if txErr != nil {
 			return txErr
 		}
 
-		// Update Payment ID
-		txErr = o.repository.GetOrder().Update(ctx, tx, &models.Order{
-			PaymentID: paymentResponse.UUID,
-		}, order.UUID)
+		txErr = o.startSaga(ctx, tx, order.ID)
 		if txErr != nil {
 			return txErr
 		}
+
+		// The payment links are created by the outbox relay after commit
+		description := fmt.Sprintf("Pembayaran Sewa %s", fields[0].FieldName)
+		if series != nil {
+			description = fmt.Sprintf("Pembayaran Sewa %s (%d minggu)", fields[0].FieldName, len(fields))
+		}
+		itemDetails := []dto.ItemDetails{
+			{
+				ID:       uuid.New(),
+				Name:     description,
+				Amount:   totalAmount,
+				Quantity: 1,
+			},
+		}
+
+		// The discount is a negative item, so the items still add up to the amount
+		if voucher != nil {
+			itemDetails = append(itemDetails, dto.ItemDetails{
+				ID:       uuid.New(),
+				Name:     fmt.Sprintf("Diskon %s", voucher.Code),
+				Amount:   -discount,
+				Quantity: 1,
+			})
+		}
+
+		paymentRequests := []dto.PaymentRequest{
+			{
+				OrderID:     order.UUID,
+				ExpiredAt:   expiredAt,
+				Amount:      order.Amount,
+				Description: description,
+				CustomerDetail: dto.CustomerDetail{
+					Name:  user.Username,
+					Email: user.Email,
+					Phone: user.PhoneNumber,
+				},
+				ItemDetails: itemDetails,
+			},
+		}
+
+		if shareCount > 0 {
+			shares, paymentRequests, txErr = o.createShares(ctx, tx, order, shareAmounts, &paymentRequests[0])
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		for _, paymentRequest := range paymentRequests {
+			payload, txErr := json.Marshal(&paymentRequest)
+			if txErr != nil {
+				return txErr
+			}
+
+			// The entry is leased to this request, the relay only picks it up if the request does not get to it
+			outbox, txErr := o.repository.GetOrderOutbox().Create(ctx, tx, &models.OrderOutbox{
+				OrderID:       order.ID,
+				EventType:     constants.CreatePaymentLinkEvent,
+				Payload:       string(payload),
+				NextAttemptAt: time.Now().Add(o.outboxLease()),
+			})
+			if txErr != nil {
+				return txErr
+			}
+			outboxes = append(outboxes, outbox)
+		}
 		return nil
 	})
 	if err != nil {
+		// Release the hold, the order was not created
+		releaseErr := o.client.GetField().UpdateStatus(&dto.UpdateFieldScheduleStatusRequest{
+			FieldScheduleIDs: fieldScheduleIDs,
+			Status:           constants.AvailableStatus.String(),
+			OrderID:          orderUUID,
+		})
+		if releaseErr != nil {
+			logrus.Errorf("failed to release field schedules: %v", releaseErr)
+		}
 		return nil, err
 	}
 
+	// Try to relay right away, the worker retries if payment-service is unavailable
+	paymentLinks := make([]string, len(outboxes))
+	for i, outbox := range outboxes {
+		paymentResponse, err := o.dispatchOutbox(ctx, outbox)
+		if err != nil {
+			logrus.Warnf("failed to create payment link for order %s, will retry: %v", order.UUID, err)
+			continue
+		}
+		if paymentResponse != nil {
+			paymentLinks[i] = paymentResponse.PaymentLink
+		}
+	}
+
+	var (
+		paymentLink    string
+		shareResponses []dto.OrderPaymentShareResponse
+	)
+	if shareCount > 0 {
+		for i, share := range shares {
+			shareResponses = append(shareResponses, o.toShareResponse(share, paymentLinks[i]))
+		}
+	} else {
+		paymentLink = paymentLinks[0]
+	}
+
 	response := dto.OrderResponse{
 		UUID:        order.UUID,
 		Code:        order.Code,
 		UserName:    user.Name,
 		Amount:      order.Amount,
+		Discount:    order.Discount,
+		VoucherCode: order.VoucherCode,
 		Status:      order.Status.GetStatusString(),
 		OrderDate:   order.Date,
-		PaymentLink: paymentResponse.PaymentLink,
+		PaymentLink: paymentLink,
+		Shares:      shareResponses,
 		CreatedAt:   *order.CreatedAt,
 		UpdatedAt:   *order.UpdatedAt,
 	}
 	return &response, nil
 }
 
-// map the status from payment-service
-func (o *OrderService) mapPaymentStatusToOrder(request *dto.PaymentData) (constants.OrderStatus, *models.Order) {
+// Create Recurring, reserves every available occurrence of the weekly series in one order
+func (o *OrderService) CreateRecurring(
+	ctx context.Context,
+	request *dto.RecurringOrderRequest,
+) (*dto.RecurringOrderResponse, error) {
+	startDate, err := time.Parse(time.DateOnly, request.StartDate)
+	if err != nil {
+		return nil, errOrder.ErrInvalidSeriesDateRange
+	}
+
+	// A series covers one season at most
+	endDate, err := time.Parse(time.DateOnly, request.EndDate)
+	if err != nil || endDate.Before(startDate) || endDate.After(startDate.AddDate(1, 0, 0)) {
+		return nil, errOrder.ErrInvalidSeriesDateRange
+	}
+
+	schedules, err := o.client.GetField().LookupFieldSchedules(&dto.FieldScheduleLookupRequest{
+		FieldID:   request.FieldID,
+		TimeID:    request.TimeID,
+		Weekday:   *request.Weekday,
+		StartDate: request.StartDate,
+		EndDate:   request.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schedulesByDate := make(map[string]clientField.FieldData, len(schedules))
+	for _, schedule := range schedules {
+		schedulesByDate[schedule.Date] = schedule
+	}
+
 	var (
-		status constants.OrderStatus
-		order  *models.Order
+		fields      = make([]clientField.FieldData, 0, len(schedules))
+		reserved    = make([]dto.OccurrenceResponse, 0, len(schedules))
+		unavailable = make([]dto.OccurrenceResponse, 0)
 	)
 
-	// Payment Status checking
-	switch request.Status {
-	case constants.SettlementPaymentStatus:
-		status = constants.PaymentSuccess
-		order = &models.Order{
-			IsPaid:    true,
-			PaymentID: request.PaymentID,
-			PaidAt:    request.PaidAt,
-			Status:    status,
+	// Walk every matching weekday, a date without a schedule is unavailable as well
+	weekday := time.Weekday(*request.Weekday)
+	first := startDate.AddDate(0, 0, (int(weekday)-int(startDate.Weekday())+7)%7)
+	for date := first; !date.After(endDate); date = date.AddDate(0, 0, 7) {
+		schedule, ok := schedulesByDate[date.Format(time.DateOnly)]
+		if !ok {
+			unavailable = append(unavailable, dto.OccurrenceResponse{
+				Date:   date.Format(time.DateOnly),
+				Status: constants.UnscheduledStatus.String(),
+			})
+			continue
 		}
-	case constants.ExpirePaymentStatus:
-		status = constants.PaymentSuccess
-		order = &models.Order{
-			IsPaid:    false,
-			PaymentID: request.PaymentID,
-			Status:    status,
+
+		scheduleID := schedule.UUID
+		occurrence := dto.OccurrenceResponse{
+			FieldScheduleID: &scheduleID,
+			Date:            schedule.Date,
+			Time:            schedule.Time,
+			Status:          schedule.Status,
 		}
-	case constants.PendingPaymentStatus:
-		status = constants.PaymentSuccess
-		order = &models.Order{
-			IsPaid:    false,
-			PaymentID: request.PaymentID,
-			Status:    status,
+		if schedule.Status != constants.AvailableStatus.String() {
+			unavailable = append(unavailable, occurrence)
+			continue
 		}
+
+		fields = append(fields, schedule)
+		reserved = append(reserved, occurrence)
+	}
+
+	if len(fields) == 0 {
+		return nil, errOrder.ErrNoAvailableOccurrence
+	}
+
+	series := &models.OrderSeries{
+		FieldID:   uuid.MustParse(request.FieldID),
+		TimeID:    uuid.MustParse(request.TimeID),
+		Weekday:   *request.Weekday,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+	order, err := o.checkout(ctx, fields, series, request.VoucherCode, 0)
+	if err != nil {
+		return nil, err
 	}
-	return status, order
+
+	return &dto.RecurringOrderResponse{
+		SeriesID:    series.UUID,
+		Order:       *order,
+		Reserved:    reserved,
+		Unavailable: unavailable,
+	}, nil
 }
 
-// Handle Payment
-func (o *OrderService) HandlePayment(ctx context.Context, request *dto.PaymentData) error {
+// Check whether the user is allowed to cancel the order
+func (o *OrderService) validateCancellation(user *clientUser.UserData, order *models.Order) error {
+	if user.Role == constants.Customer && order.UserID != user.UUID {
+		return errConstant.ErrForbidden
+	}
+
+	switch order.Status {
+	case constants.Pending, constants.PendingPayment:
+		return nil
+	case constants.PaymentSuccess, constants.PartiallyRefunded:
+		// Admin can always cancel, customer only within the cancellation window
+		if user.Role == constants.Admin {
+			return nil
+		}
+
+		window := time.Duration(config.Config.OrderCancellationWindowInMinutes) * time.Minute
+		if order.PaidAt == nil || time.Now().After(order.PaidAt.Add(window)) {
+			return errOrder.ErrCancellationWindowExpired
+		}
+		return nil
+	default:
+		return errOrder.ErrOrderCannotBeCancelled
+	}
+}
+
+// Cancel
+func (o *OrderService) Cancel(ctx context.Context, orderUUID string) (*dto.OrderResponse, error) {
 	var (
-		err, txErr          error
-		order               *models.Order
-		orderFieldSchedules []models.OrderField
+		order *models.Order
+		user  = ctx.Value(constants.User).(*clientUser.UserData)
+		err   error
 	)
 
-	status, body := o.mapPaymentStatusToOrder(request)
-	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
-		txErr = o.repository.GetOrder().Update(ctx, tx, body, request.OrderID)
-		if txErr != nil {
-			return txErr
+	order, err = o.repository.GetOrder().FindByUUID(ctx, orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.validateCancellation(user, order)
+	if err != nil {
+		return nil, err
+	}
+
+	// The payment is cancelled or refunded from the outbox within the transition
+	actor := constants.CustomerActor
+	if user.Role == constants.Admin {
+		actor = constants.AdminActor
+	}
+
+	err = o.transition(ctx, order, constants.Cancelled, actor, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.GetByUUID(ctx, order.UUID.String())
+}
+
+// Cancel Occurrence, releases a single slot of a paid order and refunds its part, the last one cancels the whole order
+func (o *OrderService) CancelOccurrence(
+	ctx context.Context,
+	orderUUID string,
+	fieldScheduleID string,
+) (*dto.OrderResponse, error) {
+	user := ctx.Value(constants.User).(*clientUser.UserData)
+	order, err := o.repository.GetOrder().FindByUUID(ctx, orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.validateCancellation(user, order)
+	if err != nil {
+		return nil, err
+	}
+
+	// An unpaid order has a single payment link for all slots, so it is cancelled as a whole
+	if order.Status != constants.PaymentSuccess && order.Status != constants.PartiallyRefunded {
+		return nil, errOrder.ErrOccurrenceCannotBeCancelled
+	}
+
+	occurrence, err := o.repository.GetOrderField().FindByOrderIDAndFieldScheduleID(ctx, order.ID, fieldScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if occurrence.CancelledAt != nil {
+		return nil, errOrder.ErrOccurrenceCannotBeCancelled
+	}
+
+	occurrences, err := o.repository.GetOrderField().FindByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := 0
+	for _, item := range occurrences {
+		if item.CancelledAt == nil {
+			active++
 		}
+	}
+	if active <= 1 {
+		return o.Cancel(ctx, orderUUID)
+	}
+
+	actor := constants.CustomerActor
+	if user.Role == constants.Admin {
+		actor = constants.AdminActor
+	}
 
-		order, txErr = o.repository.GetOrder().FindByUUID(ctx, request.OrderID.String())
+	// field-service releases the slot and payment-service refunds it from the outbox
+	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		txErr := o.repository.GetOrderField().Cancel(ctx, tx, occurrence.ID)
 		if txErr != nil {
 			return txErr
 		}
 
 		txErr = o.repository.GetOrderHistory().Create(ctx, tx, &dto.OrderHistoryRequest{
-			Status:  status.GetStatusString(),
+			Status:  constants.OccurrenceCancelledString,
+			Actor:   actor,
 			OrderID: order.ID,
 		})
+		if txErr != nil {
+			return txErr
+		}
 
-		// check the status from payment-service
-		if request.Status == constants.SettlementPaymentStatus {
-			orderFieldSchedules, txErr = o.repository.GetOrderField().FindByOrderID(ctx, order.ID)
-			if txErr != nil {
-				return txErr
-			}
+		txErr = o.enqueueEvent(ctx, tx, event.OrderOccurrenceCancelled, order, order.Status,
+			[]uuid.UUID{occurrence.FieldScheduleID})
+		if txErr != nil {
+			return txErr
+		}
 
-			FieldScheduleIDs := make([]string, 0, len(orderFieldSchedules))
-			for _, item := range orderFieldSchedules {
-				FieldScheduleIDs = append(FieldScheduleIDs, item.FieldScheduleID.String())
-			}
+		return o.refundOccurrence(ctx, tx, order, occurrence, occurrences)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			txErr = o.client.GetField().UpdateStatus(&dto.UpdateFieldScheduleStatusRequest{
-				FieldScheduleIDs: FieldScheduleIDs,
-			})
-			if txErr != nil {
-				return txErr
+	return o.GetByUUID(ctx, order.UUID.String())
+}
+
+// Refund the part of the paid order the occurrence is worth, from every paid share of a split order.
+// The discount of the order is shared by its occurrences.
+func (o *OrderService) refundOccurrence(
+	ctx context.Context,
+	tx *gorm.DB,
+	order *models.Order,
+	occurrence *models.OrderField,
+	occurrences []models.OrderField,
+) error {
+	ratio := occurrenceRatio(occurrence, occurrences)
+	reason := fmt.Sprintf("Jadwal pesanan %s dibatalkan", order.Code)
+
+	payments := make(map[uuid.UUID]float64)
+	if order.ShareCount == 0 {
+		if order.PaymentID != uuid.Nil {
+			payments[order.PaymentID] = order.Amount
+		}
+	} else {
+		shares, err := o.repository.GetOrderPaymentShare().FindByOrderID(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		for _, share := range shares {
+			if share.Status == constants.SharePaid && share.PaymentID != nil {
+				payments[*share.PaymentID] = share.Amount
 			}
 		}
+	}
+
+	for paymentID, paid := range payments {
+		amount := math.Floor(paid * ratio)
+		if amount < 1 {
+			continue
+		}
+
+		err := o.enqueuePaymentAction(ctx, tx, order.ID, constants.RefundPaymentEvent, &dto.PaymentOutboxRequest{
+			PaymentID: paymentID,
+			Amount:    &amount,
+			Reason:    reason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Part of the order price the occurrence is worth
+func occurrenceRatio(occurrence *models.OrderField, occurrences []models.OrderField) float64 {
+	var total float64
+	for _, item := range occurrences {
+		total += item.Amount
+	}
+
+	// The occurrences of an order placed before their price was stored are worth the same
+	if total <= 0 || occurrence.Amount <= 0 {
+		return 1 / float64(len(occurrences))
+	}
+	return occurrence.Amount / total
+}
+
+// Update Status (used by admin to complete the order or mark it as no-show)
+func (o *OrderService) UpdateStatus(
+	ctx context.Context,
+	orderUUID string,
+	request *dto.UpdateOrderStatusRequest,
+) (*dto.OrderResponse, error) {
+	status := request.Status.GetStatusInt()
+
+	// Cancellation also has to cancel the payment
+	if status == constants.Cancelled {
+		return o.Cancel(ctx, orderUUID)
+	}
+
+	order, err := o.repository.GetOrder().FindByUUID(ctx, orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.transition(ctx, order, status, constants.AdminActor, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.GetByUUID(ctx, order.UUID.String())
+}
+
+// Handle Payment
+func (o *OrderService) HandlePayment(ctx context.Context, eventID string, request *dto.PaymentData) error {
+	// Duplicate delivery of an event that is already applied
+	if eventID != "" {
+		processed, err := o.repository.GetProcessedEvent().Exists(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if processed {
+			logrus.Infof("payment event %s is already processed, ignoring", eventID)
+			return nil
+		}
+	}
+
+	err := o.applyPayment(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if eventID == "" {
 		return nil
+	}
+	return o.repository.GetProcessedEvent().Create(ctx, eventID, string(request.Status))
+}
+
+// Apply the payment status to the order, stale or repeated statuses are ignored
+func (o *OrderService) applyPayment(ctx context.Context, request *dto.PaymentData) error {
+	// The payment of a share is keyed by the share instead of the order
+	share, err := o.repository.GetOrderPaymentShare().FindByUUID(ctx, request.OrderID.String())
+	if err != nil {
+		return err
+	}
+	if share != nil {
+		return o.applySharePayment(ctx, share, request)
+	}
+
+	order, err := o.repository.GetOrder().FindByUUID(ctx, request.OrderID.String())
+	if err != nil {
+		return err
+	}
+
+	switch request.Status {
+	case constants.PendingPaymentStatus:
+		return o.transitionFromEvent(ctx, order, constants.PendingPayment, constants.PaymentActor, &models.Order{
+			PaymentID: request.PaymentID,
+			ExpiredAt: request.ExpiredAt,
+		})
+	case constants.SettlementPaymentStatus, constants.CapturePaymentStatus:
+		if o.isClosedUnpaid(order) {
+			return o.refundLateSettlement(ctx, order, request.PaymentID)
+		}
+
+		// Only version 2 of the payment event carries the amount
+		if request.Amount != nil && *request.Amount != order.Amount {
+			logrus.Warnf("paid amount %.2f of order %s differs from the order amount %.2f", *request.Amount, order.UUID, order.Amount)
+		}
+		return o.transitionFromEvent(ctx, order, constants.PaymentSuccess, constants.PaymentActor, &models.Order{
+			IsPaid:    true,
+			PaymentID: request.PaymentID,
+			PaidAt:    request.PaidAt,
+		})
+	case constants.ExpirePaymentStatus:
+		return o.transitionFromEvent(ctx, order, constants.Expired, constants.PaymentActor, nil)
+	case constants.ChallengePaymentStatus:
+		// The capture is under fraud review, the final status comes once it is accepted or denied
+		return o.transitionFromEvent(ctx, order, constants.PaymentChallenged, constants.PaymentActor, &models.Order{
+			PaymentID: request.PaymentID,
+		})
+	case constants.DenyPaymentStatus, constants.CancelPaymentStatus, constants.FailurePaymentStatus:
+		return o.transitionFromEvent(ctx, order, constants.Cancelled, constants.PaymentActor, nil)
+	case constants.RefundPaymentStatus:
+		return o.transitionFromEvent(ctx, order, constants.Refunded, constants.PaymentActor, nil)
+	case constants.PartialRefundPaymentStatus:
+		return o.transitionFromEvent(ctx, order, constants.PartiallyRefunded, constants.PaymentActor, nil)
+	}
+
+	logrus.Warnf("unknown payment status %s of order %s", request.Status, order.UUID)
+	return nil
+}
+
+// The order expired or was cancelled before it was paid
+func (o *OrderService) isClosedUnpaid(order *models.Order) bool {
+	return !order.IsPaid && (order.Status == constants.Expired || order.Status == constants.Cancelled)
+}
+
+// A payment settled after its order expired or was cancelled is refunded from the outbox, nothing is booked for it
+func (o *OrderService) refundLateSettlement(ctx context.Context, order *models.Order, paymentID uuid.UUID) error {
+	logrus.Warnf("payment %s of order %s settled after the order is %s, refunding",
+		paymentID, order.UUID, order.Status.GetStatusString())
+	return o.enqueuePaymentAction(ctx, o.repository.GetTx(), order.ID, constants.RefundPaymentEvent, &dto.PaymentOutboxRequest{
+		PaymentID: paymentID,
+		Reason:    fmt.Sprintf("Pesanan %s %s", order.Code, order.Status.GetStatusString()),
 	})
+}
+
+// Expire Orders (used by the expiry sweeper)
+func (o *OrderService) ExpireOrders(ctx context.Context) error {
+	orders, err := o.repository.GetOrder().FindAllExpiredPending(ctx, time.Now())
 	if err != nil {
-		return nil
+		return err
+	}
+
+	for _, order := range orders {
+		// The transition cancels the payment link from the outbox, so it can no longer be paid.
+		// A settlement that still comes in is refunded.
+		err = o.transitionFromEvent(ctx, &order, constants.Expired, constants.SystemActor, nil)
+		if err != nil {
+			logrus.Errorf("failed to expire order %s: %v", order.UUID, err)
+		}
+	}
+	return nil
+}
+
+// Delay before the next relay attempt, doubled on every failure
+func (o *OrderService) outboxBackoff(attempts int) time.Duration {
+	backoff := 5 * time.Second
+	for i := 1; i < attempts && backoff < 10*time.Minute; i++ {
+		backoff *= 2
+	}
+	if backoff > 10*time.Minute {
+		backoff = 10 * time.Minute
+	}
+	return backoff
+}
+
+// How long a relay holds the entry it works on, the entry is picked up again after it
+func (o *OrderService) outboxLease() time.Duration {
+	lease := config.Config.Worker.OutboxLeaseInSeconds
+	if lease <= 0 {
+		lease = 60
+	}
+	return time.Duration(lease) * time.Second
+}
+
+// Record a failed relay attempt, a payment link entry is given up after the max attempts
+func (o *OrderService) failOutbox(ctx context.Context, outbox *models.OrderOutbox, cause error) error {
+	maxAttempts := config.Config.Worker.OutboxMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 10
+	}
+
+	lastError := cause.Error()
+	outbox.Attempts++
+	outbox.LastError = &lastError
+	outbox.NextAttemptAt = time.Now().Add(o.outboxBackoff(outbox.Attempts))
+	// Only the payment link can be given up and compensated, the other entries are retried until they are relayed
+	if outbox.EventType == constants.CreatePaymentLinkEvent && outbox.Attempts >= maxAttempts {
+		outbox.Status = constants.OutboxFailed
+		logrus.Errorf("outbox %s has reached the max attempts: %v", outbox.UUID, cause)
+	} else if outbox.Attempts >= maxAttempts {
+		logrus.Errorf("outbox %s has failed %d times, retrying: %v", outbox.UUID, outbox.Attempts, cause)
+	}
+	err := o.repository.GetOrderOutbox().Update(ctx, outbox)
+	if err != nil {
+		return err
+	}
+
+	if outbox.Status == constants.OutboxFailed {
+		return o.compensatePayment(ctx, outbox, cause)
+	}
+	return nil
+}
+
+// Create the payment link of the outbox entry and attach it to the order
+func (o *OrderService) dispatchOutbox(ctx context.Context, outbox *models.OrderOutbox) (*clientPayment.PaymentData, error) {
+	var request dto.PaymentRequest
+	err := json.Unmarshal([]byte(outbox.Payload), &request)
+	if err != nil {
+		return nil, o.failOutbox(ctx, outbox, err)
+	}
+	request.IdempotencyKey = outbox.UUID.String()
+
+	order, err := o.repository.GetOrder().FindByID(ctx, outbox.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The order was cancelled or expired before the relay got to it
+	now := time.Now()
+	if order.Status != constants.PendingPayment {
+		outbox.Status = constants.OutboxProcessed
+		outbox.ProcessedAt = &now
+		return nil, o.repository.GetOrderOutbox().Update(ctx, outbox)
+	}
+
+	payment, err := o.client.GetPayment().CreatePaymentLink(ctx, &request)
+	if err != nil {
+		failErr := o.failOutbox(ctx, outbox, err)
+		if failErr != nil {
+			return nil, failErr
+		}
+		return nil, err
+	}
+
+	err = o.attachPayment(ctx, order, request.OrderID, payment.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	outbox.Status = constants.OutboxProcessed
+	outbox.ProcessedAt = &now
+	outbox.LastError = nil
+	err = o.repository.GetOrderOutbox().Update(ctx, outbox)
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Attach the created payment to the order, or to the share it was created for.
+// The saga moves on once every payment of the order is created.
+func (o *OrderService) attachPayment(ctx context.Context, order *models.Order, paymentOrderID, paymentID uuid.UUID) error {
+	if paymentOrderID == order.UUID {
+		err := o.repository.GetOrder().Update(ctx, o.repository.GetTx(), &models.Order{
+			PaymentID: paymentID,
+		}, order.UUID)
+		if err != nil {
+			return err
+		}
+		return o.completeSagaStep(ctx, o.repository.GetTx(), order.ID, constants.CreatePaymentStep)
+	}
+
+	share, err := o.repository.GetOrderPaymentShare().FindByUUID(ctx, paymentOrderID.String())
+	if err != nil {
+		return err
+	}
+	if share == nil {
+		return errOrder.ErrOrderNotFound
+	}
+	err = o.repository.GetOrderPaymentShare().UpdatePaymentID(ctx, share.ID, paymentID)
+	if err != nil {
+		return err
+	}
+
+	shares, err := o.repository.GetOrderPaymentShare().FindByOrderID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	for _, item := range shares {
+		if item.PaymentID == nil && item.ID != share.ID {
+			return nil
+		}
+	}
+	return o.completeSagaStep(ctx, o.repository.GetTx(), order.ID, constants.CreatePaymentStep)
+}
+
+// Store the refund or cancellation of a payment in the outbox within the transaction of the order change
+func (o *OrderService) enqueuePaymentAction(
+	ctx context.Context,
+	tx *gorm.DB,
+	orderID uint,
+	eventType constants.OutboxEventType,
+	request *dto.PaymentOutboxRequest,
+) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.repository.GetOrderOutbox().Create(ctx, tx, &models.OrderOutbox{
+		OrderID:       orderID,
+		EventType:     eventType,
+		Payload:       string(payload),
+		NextAttemptAt: time.Now(),
+	})
+	return err
+}
+
+// Cancel the payment of the order closed within the transition, a settled payment cannot be cancelled so it is refunded
+func (o *OrderService) closePayment(
+	ctx context.Context,
+	tx *gorm.DB,
+	order *models.Order,
+	from constants.OrderStatus,
+	status constants.OrderStatus,
+) error {
+	eventType := constants.CancelPaymentEvent
+	if from == constants.PaymentSuccess || from == constants.PartiallyRefunded {
+		eventType = constants.RefundPaymentEvent
+	}
+
+	return o.enqueuePaymentAction(ctx, tx, order.ID, eventType, &dto.PaymentOutboxRequest{
+		PaymentID: order.PaymentID,
+		Reason:    fmt.Sprintf("Pesanan %s %s", order.Code, status.GetStatusString()),
+	})
+}
+
+// Refund or cancel the payment of the outbox entry. A call that failed after payment-service applied it
+// is done once the payment shows the result.
+func (o *OrderService) settleOutbox(ctx context.Context, outbox *models.OrderOutbox) error {
+	var request dto.PaymentOutboxRequest
+	err := json.Unmarshal([]byte(outbox.Payload), &request)
+	if err != nil {
+		return o.failOutbox(ctx, outbox, err)
+	}
+
+	if outbox.EventType == constants.RefundPaymentEvent {
+		err = o.client.GetPayment().RefundPayment(ctx, request.PaymentID, &dto.PaymentRefundRequest{
+			Amount:    request.Amount,
+			Reason:    request.Reason,
+			RefundKey: outbox.UUID.String(),
+		})
+	} else {
+		_, err = o.client.GetPayment().CancelPayment(ctx, request.PaymentID)
+	}
+	if err != nil && !o.isPaymentSettled(ctx, outbox.EventType, request.PaymentID) {
+		failErr := o.failOutbox(ctx, outbox, err)
+		if failErr != nil {
+			return failErr
+		}
+		return err
+	}
+
+	now := time.Now()
+	outbox.Status = constants.OutboxProcessed
+	outbox.ProcessedAt = &now
+	outbox.LastError = nil
+	return o.repository.GetOrderOutbox().Update(ctx, outbox)
+}
+
+// Whether the payment is already refunded, or no longer pending for a cancellation.
+// The relay has no user token, so the current status is the last one of the internal payment history.
+func (o *OrderService) isPaymentSettled(ctx context.Context, eventType constants.OutboxEventType, paymentID uuid.UUID) bool {
+	histories, err := o.client.GetPayment().GetPaymentHistory(ctx, paymentID)
+	if err != nil || len(histories) == 0 {
+		return false
+	}
+
+	status := constants.PaymentStatusString(histories[len(histories)-1].Status)
+	if eventType == constants.RefundPaymentEvent {
+		return status == constants.RefundPaymentStatus
+	}
+	return status != constants.PendingPaymentStatus
+}
+
+// Relay Outbox (used by the outbox relay worker)
+func (o *OrderService) RelayOutbox(ctx context.Context) error {
+	batchSize := config.Config.Worker.OutboxBatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	now := time.Now()
+	outboxes, err := o.repository.GetOrderOutbox().FindAllDue(ctx, now, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, outbox := range outboxes {
+		// Another relay is already on the entry
+		claimed, err := o.repository.GetOrderOutbox().Claim(ctx, &outbox, now, now.Add(o.outboxLease()))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		switch outbox.EventType {
+		case constants.OrderEvent:
+			err = o.publishOutbox(ctx, &outbox)
+		case constants.RefundPaymentEvent, constants.CancelPaymentEvent:
+			err = o.settleOutbox(ctx, &outbox)
+		default:
+			_, err = o.dispatchOutbox(ctx, &outbox)
+		}
+		if err != nil {
+			logrus.Errorf("failed to relay outbox %s: %v", outbox.UUID, err)
+			continue
+		}
 	}
 	return nil
 }
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	clientPayment "order-service/clients/payment"
+	"order-service/constants"
+	errOrder "order-service/constants/error/order"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Split the amount into whole rupiah shares, the first share takes the remainder
+func splitShareAmounts(amount float64, count int) ([]float64, error) {
+	base := math.Floor(amount / float64(count))
+	if base < 1 {
+		return nil, errOrder.ErrShareAmountTooSmall
+	}
+
+	amounts := make([]float64, count)
+	for i := range amounts {
+		amounts[i] = base
+	}
+	amounts[0] = amount - base*float64(count-1)
+	return amounts, nil
+}
+
+// Create the payment shares of the order and one payment request per share
+func (o *OrderService) createShares(
+	ctx context.Context,
+	tx *gorm.DB,
+	order *models.Order,
+	amounts []float64,
+	request *dto.PaymentRequest,
+) ([]models.OrderPaymentShare, []dto.PaymentRequest, error) {
+	params := make([]models.OrderPaymentShare, 0, len(amounts))
+	for i, amount := range amounts {
+		params = append(params, models.OrderPaymentShare{
+			OrderID:  order.ID,
+			Sequence: i + 1,
+			Amount:   amount,
+		})
+	}
+
+	shares, err := o.repository.GetOrderPaymentShare().Create(ctx, tx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paymentRequests := make([]dto.PaymentRequest, 0, len(shares))
+	for _, share := range shares {
+		description := fmt.Sprintf("%s (bagian %d/%d)", request.Description, share.Sequence, len(shares))
+		paymentRequests = append(paymentRequests, dto.PaymentRequest{
+			OrderID:        share.UUID,
+			ExpiredAt:      request.ExpiredAt,
+			Amount:         share.Amount,
+			Description:    description,
+			CustomerDetail: request.CustomerDetail,
+			ItemDetails: []dto.ItemDetails{
+				{
+					ID:       uuid.New(),
+					Name:     description,
+					Amount:   share.Amount,
+					Quantity: 1,
+				},
+			},
+		})
+	}
+	return shares, paymentRequests, nil
+}
+
+func (o *OrderService) toShareResponse(share models.OrderPaymentShare, paymentLink string) dto.OrderPaymentShareResponse {
+	return dto.OrderPaymentShareResponse{
+		UUID:        share.UUID,
+		Sequence:    share.Sequence,
+		Amount:      share.Amount,
+		Status:      share.Status.GetStatusString(),
+		PaymentLink: paymentLink,
+		PaidAt:      share.PaidAt,
+	}
+}
+
+// Payment shares of a split order with their payment links
+func (o *OrderService) getShares(ctx context.Context, order *models.Order) ([]dto.OrderPaymentShareResponse, error) {
+	if order.ShareCount == 0 {
+		return nil, nil
+	}
+
+	shares, err := o.repository.GetOrderPaymentShare().FindByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentIDs := make([]uuid.UUID, 0, len(shares))
+	for _, share := range shares {
+		if share.PaymentID != nil {
+			paymentIDs = append(paymentIDs, *share.PaymentID)
+		}
+	}
+
+	payments := make(map[uuid.UUID]*clientPayment.PaymentData)
+	if len(paymentIDs) > 0 {
+		payments, err = o.client.GetPayment().GetPaymentsByUUIDs(ctx, paymentIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	responses := make([]dto.OrderPaymentShareResponse, 0, len(shares))
+	for _, share := range shares {
+		var paymentLink string
+		if share.PaymentID != nil {
+			if payment, ok := payments[*share.PaymentID]; ok {
+				paymentLink = payment.PaymentLink
+			}
+		}
+		responses = append(responses, o.toShareResponse(share, paymentLink))
+	}
+	return responses, nil
+}
+
+// Apply the payment status to the share, the order follows once every share is paid or any share fails
+func (o *OrderService) applySharePayment(
+	ctx context.Context,
+	share *models.OrderPaymentShare,
+	request *dto.PaymentData,
+) error {
+	order, err := o.repository.GetOrder().FindByID(ctx, share.OrderID)
+	if err != nil {
+		return err
+	}
+
+	paymentID := request.PaymentID
+	share.PaymentID = &paymentID
+
+	switch request.Status {
+	case constants.PendingPaymentStatus:
+		return o.repository.GetOrderPaymentShare().Update(ctx, share)
+	case constants.SettlementPaymentStatus, constants.CapturePaymentStatus:
+		if share.Status == constants.SharePaid {
+			return nil
+		}
+		if o.isClosedUnpaid(order) {
+			return o.refundLateSettlement(ctx, order, request.PaymentID)
+		}
+
+		share.Status = constants.SharePaid
+		share.PaidAt = request.PaidAt
+		err = o.repository.GetOrderPaymentShare().Update(ctx, share)
+		if err != nil {
+			return err
+		}
+
+		// Counted after the share is stored, so the last share to settle always sees every share paid
+		unpaid, err := o.repository.GetOrderPaymentShare().CountUnpaidByOrderID(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if unpaid > 0 {
+			logrus.Infof("share %d of order %s is paid, waiting for %d more", share.Sequence, order.UUID, unpaid)
+			return nil
+		}
+
+		return o.transitionFromEvent(ctx, order, constants.PaymentSuccess, constants.PaymentActor, &models.Order{
+			IsPaid: true,
+			PaidAt: request.PaidAt,
+		})
+	case constants.ExpirePaymentStatus:
+		share.Status = constants.ShareExpired
+		err = o.repository.GetOrderPaymentShare().Update(ctx, share)
+		if err != nil {
+			return err
+		}
+		return o.transitionFromEvent(ctx, order, constants.Expired, constants.PaymentActor, nil)
+	case constants.ChallengePaymentStatus:
+		err = o.repository.GetOrderPaymentShare().Update(ctx, share)
+		if err != nil {
+			return err
+		}
+		return o.transitionFromEvent(ctx, order, constants.PaymentChallenged, constants.PaymentActor, nil)
+	case constants.DenyPaymentStatus, constants.CancelPaymentStatus, constants.FailurePaymentStatus:
+		share.Status = constants.ShareCancelled
+		err = o.repository.GetOrderPaymentShare().Update(ctx, share)
+		if err != nil {
+			return err
+		}
+		return o.transitionFromEvent(ctx, order, constants.Cancelled, constants.PaymentActor, nil)
+	case constants.RefundPaymentStatus:
+		share.Status = constants.ShareRefunded
+		return o.repository.GetOrderPaymentShare().Update(ctx, share)
+	case constants.PartialRefundPaymentStatus:
+		logrus.Infof("share %d of order %s is partially refunded", share.Sequence, order.UUID)
+		return nil
+	}
+
+	logrus.Warnf("unknown payment status %s of share %d of order %s", request.Status, share.Sequence, order.UUID)
+	return nil
+}
+
+// Close the shares of a split order that ended within its transition, the paid shares are refunded and the others cancelled.
+// The outbox relay retries them until payment-service accepts.
+func (o *OrderService) closeShares(ctx context.Context, tx *gorm.DB, order *models.Order, status constants.OrderStatus) error {
+	shares, err := o.repository.GetOrderPaymentShare().FindByOrderID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, share := range shares {
+		// The share has no payment link yet
+		if share.PaymentID == nil {
+			continue
+		}
+
+		var eventType constants.OutboxEventType
+		switch share.Status {
+		case constants.SharePaid:
+			eventType = constants.RefundPaymentEvent
+		case constants.SharePending:
+			eventType = constants.CancelPaymentEvent
+		default:
+			continue
+		}
+
+		err = o.enqueuePaymentAction(ctx, tx, order.ID, eventType, &dto.PaymentOutboxRequest{
+			PaymentID: *share.PaymentID,
+			Reason:    fmt.Sprintf("Pesanan %s %s", order.Code, status.GetStatusString()),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	errOrder "order-service/constants/error/order"
+	"reflect"
+	"testing"
+)
+
+func TestSplitShareAmounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  float64
+		count   int
+		want    []float64
+		wantErr error
+	}{
+		{
+			name:   "even split",
+			amount: 300000,
+			count:  3,
+			want:   []float64{100000, 100000, 100000},
+		},
+		{
+			name:   "first share takes the remainder",
+			amount: 100000,
+			count:  3,
+			want:   []float64{33334, 33333, 33333},
+		},
+		{
+			name:   "fractional amount",
+			amount: 100000.5,
+			count:  2,
+			want:   []float64{50000.5, 50000},
+		},
+		{
+			name:   "single share",
+			amount: 150000,
+			count:  1,
+			want:   []float64{150000},
+		},
+		{
+			name:   "one rupiah each",
+			amount: 2,
+			count:  2,
+			want:   []float64{1, 1},
+		},
+		{
+			name:    "too small",
+			amount:  3,
+			count:   4,
+			wantErr: errOrder.ErrShareAmountTooSmall,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitShareAmounts(tt.amount, tt.count)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("splitShareAmounts() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("splitShareAmounts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
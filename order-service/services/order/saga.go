@@ -0,0 +1,300 @@
+package services
+
+import (
+	"common/event"
+	"context"
+	"errors"
+	"order-service/config"
+	"order-service/constants"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+	"gorm.io/gorm"
+)
+
+// Start the booking saga of the new order, its field schedules are already held
+func (o *OrderService) startSaga(ctx context.Context, tx *gorm.DB, orderID uint) error {
+	saga, err := o.repository.GetOrderSaga().Create(ctx, tx, &models.OrderSaga{
+		OrderID: orderID,
+		Step:    constants.CreatePaymentStep,
+		Status:  constants.SagaRunning,
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.repository.GetOrderSaga().CreateStep(ctx, tx, &models.OrderSagaStep{
+		SagaID: saga.ID,
+		Step:   constants.ReserveSlotStep,
+		Status: constants.SagaStepSucceeded,
+	})
+}
+
+// Record the step of the running saga as succeeded and move on to the next one.
+// The steps skipped by an event that came early succeeded too, a step the saga is past is ignored.
+func (o *OrderService) completeSagaStep(ctx context.Context, tx *gorm.DB, orderID uint, step constants.SagaStep) error {
+	saga, err := o.repository.GetOrderSaga().FindByOrderID(ctx, orderID)
+	if err != nil || saga == nil || saga.Status != constants.SagaRunning {
+		return err
+	}
+
+	current := slices.Index(constants.SagaSteps, saga.Step)
+	index := slices.Index(constants.SagaSteps, step)
+	if index < current {
+		return nil
+	}
+
+	for _, item := range constants.SagaSteps[current : index+1] {
+		err = o.repository.GetOrderSaga().CreateStep(ctx, tx, &models.OrderSagaStep{
+			SagaID: saga.ID,
+			Step:   item,
+			Status: constants.SagaStepSucceeded,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if index == len(constants.SagaSteps)-1 {
+		saga.Status = constants.SagaCompleted
+	} else {
+		saga.Step = constants.SagaSteps[index+1]
+	}
+	return o.repository.GetOrderSaga().Update(ctx, tx, saga)
+}
+
+// Record the step of the running saga as failed, the saga compensates the steps before it
+func (o *OrderService) failSagaStep(
+	ctx context.Context,
+	tx *gorm.DB,
+	orderID uint,
+	step constants.SagaStep,
+	cause error,
+) error {
+	saga, err := o.repository.GetOrderSaga().FindByOrderID(ctx, orderID)
+	if err != nil || saga == nil || saga.Status != constants.SagaRunning {
+		return err
+	}
+
+	lastError := cause.Error()
+	err = o.repository.GetOrderSaga().CreateStep(ctx, tx, &models.OrderSagaStep{
+		SagaID: saga.ID,
+		Step:   step,
+		Status: constants.SagaStepFailed,
+		Error:  &lastError,
+	})
+	if err != nil {
+		return err
+	}
+
+	saga.Step = step
+	saga.Status = constants.SagaCompensating
+	saga.LastError = &lastError
+	logrus.Warnf("saga of order %d failed at %s, compensating: %v", orderID, step, cause)
+	return o.repository.GetOrderSaga().Update(ctx, tx, saga)
+}
+
+// End the saga as compensated, or as failed when the compensation itself failed
+func (o *OrderService) finishSaga(ctx context.Context, tx *gorm.DB, saga *models.OrderSaga, cause error) error {
+	step := models.OrderSagaStep{
+		SagaID: saga.ID,
+		Step:   saga.Step,
+		Status: constants.SagaStepCompensated,
+	}
+	saga.Status = constants.SagaCompensated
+	if cause != nil {
+		lastError := cause.Error()
+		step.Status = constants.SagaStepFailed
+		step.Error = &lastError
+		saga.Status = constants.SagaFailed
+		saga.LastError = &lastError
+	}
+
+	err := o.repository.GetOrderSaga().CreateStep(ctx, tx, &step)
+	if err != nil {
+		return err
+	}
+	return o.repository.GetOrderSaga().Update(ctx, tx, saga)
+}
+
+// Follow the order transition in the saga, called within the transaction of the transition.
+// A running saga ends when the order is closed, a compensating one once nothing is left to refund.
+func (o *OrderService) advanceSaga(
+	ctx context.Context,
+	tx *gorm.DB,
+	orderID uint,
+	from constants.OrderStatus,
+	status constants.OrderStatus,
+) error {
+	switch status {
+	case constants.PaymentSuccess:
+		return o.completeSagaStep(ctx, tx, orderID, constants.ConfirmPaymentStep)
+	case constants.Expired, constants.Cancelled, constants.Refunded:
+	default:
+		return nil
+	}
+
+	saga, err := o.repository.GetOrderSaga().FindByOrderID(ctx, orderID)
+	if err != nil || saga == nil {
+		return err
+	}
+
+	switch {
+	case saga.Status == constants.SagaRunning,
+		saga.Status == constants.SagaCompensating && (status == constants.Refunded || from != constants.PaymentSuccess):
+		return o.finishSaga(ctx, tx, saga, nil)
+	}
+	return nil
+}
+
+// The payment link of the order could not be created, the order is cancelled so its field schedules are released
+func (o *OrderService) compensatePayment(ctx context.Context, outbox *models.OrderOutbox, cause error) error {
+	order, err := o.repository.GetOrder().FindByID(ctx, outbox.OrderID)
+	if err != nil {
+		return err
+	}
+
+	err = o.failSagaStep(ctx, o.repository.GetTx(), order.ID, constants.CreatePaymentStep, cause)
+	if err != nil {
+		return err
+	}
+	return o.transitionFromEvent(ctx, order, constants.Cancelled, constants.SystemActor, nil)
+}
+
+// Handle Field Schedule Event, the reply of field-service to the paid order
+func (o *OrderService) HandleFieldScheduleEvent(
+	ctx context.Context,
+	eventID string,
+	eventType string,
+	request *dto.FieldScheduleEventV1,
+) error {
+	processed, err := o.repository.GetProcessedEvent().Exists(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if processed {
+		logrus.Infof("field schedule event %s is already processed, ignoring", eventID)
+		return nil
+	}
+
+	order, err := o.repository.GetOrder().FindByUUID(ctx, request.OrderID.String())
+	if err != nil {
+		return err
+	}
+
+	switch eventType {
+	case event.FieldScheduleBooked:
+		err = o.completeSagaStep(ctx, o.repository.GetTx(), order.ID, constants.BookSlotStep)
+	case event.FieldScheduleBookingFailed:
+		err = o.compensateBooking(ctx, order, request.Reason)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return o.repository.GetProcessedEvent().Create(ctx, eventID, eventType)
+}
+
+// None of the field schedules of the paid order could be booked, the order is cancelled and its payment refunded
+func (o *OrderService) compensateBooking(ctx context.Context, order *models.Order, reason string) error {
+	// The order was closed while its field schedules were being booked
+	if order.Status != constants.PaymentSuccess {
+		logrus.Infof("order %s is %s, ignoring failed booking", order.UUID, order.Status.GetStatusString())
+		return nil
+	}
+
+	err := o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		// Nothing is booked for the order, so the cancellation must not release the schedules of another order
+		occurrences, txErr := o.repository.GetOrderField().FindByOrderID(ctx, order.ID)
+		if txErr != nil {
+			return txErr
+		}
+		for _, item := range occurrences {
+			if item.CancelledAt != nil {
+				continue
+			}
+			txErr = o.repository.GetOrderField().Cancel(ctx, tx, item.ID)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		return o.failSagaStep(ctx, tx, order.ID, constants.BookSlotStep, errors.New(reason))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = o.transition(ctx, order, constants.Cancelled, constants.SystemActor, nil)
+	if err != nil {
+		return err
+	}
+
+	saga, err := o.repository.GetOrderSaga().FindByOrderID(ctx, order.ID)
+	if err != nil || saga == nil || saga.Status != constants.SagaCompensating {
+		return err
+	}
+
+	// The refund is enqueued within the transition, the saga is compensated once payment-service reports it
+	if order.PaymentID != uuid.Nil {
+		return nil
+	}
+
+	// The paid shares of a split order are refunded when it is cancelled
+	return o.finishSaga(ctx, o.repository.GetTx(), saga, nil)
+}
+
+// Get Stuck Sagas, the failed sagas and the ones which have not moved for a while
+func (o *OrderService) GetStuckSagas(ctx context.Context) ([]dto.OrderSagaResponse, error) {
+	stuckAfter := config.Config.Worker.SagaStuckAfterInMinutes
+	if stuckAfter <= 0 {
+		stuckAfter = 30
+	}
+
+	sagas, err := o.repository.GetOrderSaga().FindAllStuck(ctx, time.Now().Add(-time.Duration(stuckAfter)*time.Minute))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.OrderSagaResponse, 0, len(sagas))
+	for _, saga := range sagas {
+		order, err := o.repository.GetOrder().FindByID(ctx, saga.OrderID)
+		if err != nil {
+			return nil, err
+		}
+
+		steps, err := o.repository.GetOrderSaga().FindStepsBySagaID(ctx, saga.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		stepResponses := make([]dto.OrderSagaStepResponse, 0, len(steps))
+		for _, step := range steps {
+			stepResponses = append(stepResponses, dto.OrderSagaStepResponse{
+				Step:      step.Step,
+				Status:    step.Status,
+				Error:     step.Error,
+				CreatedAt: timeOrZero(step.CreatedAt),
+			})
+		}
+
+		results = append(results, dto.OrderSagaResponse{
+			UUID:        saga.UUID,
+			OrderID:     order.UUID,
+			OrderCode:   order.Code,
+			OrderStatus: order.Status.GetStatusString(),
+			Step:        saga.Step,
+			Status:      saga.Status.GetStatusString(),
+			LastError:   saga.LastError,
+			Steps:       stepResponses,
+			CreatedAt:   timeOrZero(saga.CreatedAt),
+			UpdatedAt:   timeOrZero(saga.UpdatedAt),
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"common/event"
+	"context"
+	"order-service/constants"
+	errOrder "order-service/constants/error/order"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+	"gorm.io/gorm"
+)
+
+// Side effect on the field schedules of the order after a transition, applied by field-service from the order event
+type scheduleEffect int
+
+const (
+	noScheduleEffect scheduleEffect = iota
+	bookSchedules
+	releaseSchedules
+)
+
+type orderTransition struct {
+	from   []constants.OrderStatus
+	actors []constants.OrderActor
+	effect scheduleEffect
+	// The voucher usage is given back when the order expires or is cancelled, even after it was paid
+	releaseVoucher bool
+	// The shares of a split order are refunded or cancelled
+	closeShares bool
+	// The payment of the order is cancelled, or refunded once paid, when one of these actors closes it
+	closePaymentBy []constants.OrderActor
+	// Event published to the other services, none when empty
+	event string
+}
+
+// Allowed transitions, keyed by the target status
+var orderTransitions = map[constants.OrderStatus]orderTransition{
+	constants.PendingPayment: {
+		from:   []constants.OrderStatus{constants.Pending},
+		actors: []constants.OrderActor{constants.PaymentActor, constants.SystemActor},
+		effect: noScheduleEffect,
+	},
+	// The card payment is captured but under fraud review, the order keeps its field schedules until it is decided
+	constants.PaymentChallenged: {
+		from:   []constants.OrderStatus{constants.Pending, constants.PendingPayment},
+		actors: []constants.OrderActor{constants.PaymentActor},
+		effect: noScheduleEffect,
+	},
+	constants.PaymentSuccess: {
+		from:   []constants.OrderStatus{constants.Pending, constants.PendingPayment, constants.PaymentChallenged},
+		actors: []constants.OrderActor{constants.PaymentActor},
+		effect: bookSchedules,
+		event:  event.OrderPaid,
+	},
+	constants.Expired: {
+		from:           []constants.OrderStatus{constants.Pending, constants.PendingPayment, constants.PaymentChallenged},
+		actors:         []constants.OrderActor{constants.PaymentActor, constants.SystemActor},
+		effect:         releaseSchedules,
+		releaseVoucher: true,
+		closeShares:    true,
+		closePaymentBy: []constants.OrderActor{constants.SystemActor},
+		event:          event.OrderExpired,
+	},
+	constants.Cancelled: {
+		from: []constants.OrderStatus{
+			constants.Pending,
+			constants.PendingPayment,
+			constants.PaymentChallenged,
+			constants.PaymentSuccess,
+			constants.PartiallyRefunded,
+		},
+		actors:         []constants.OrderActor{constants.CustomerActor, constants.AdminActor, constants.PaymentActor, constants.SystemActor},
+		effect:         releaseSchedules,
+		releaseVoucher: true,
+		closeShares:    true,
+		closePaymentBy: []constants.OrderActor{constants.CustomerActor, constants.AdminActor, constants.SystemActor},
+		event:          event.OrderCancelled,
+	},
+	constants.Refunded: {
+		from:   []constants.OrderStatus{constants.PaymentSuccess, constants.PartiallyRefunded, constants.Cancelled},
+		actors: []constants.OrderActor{constants.PaymentActor},
+		effect: releaseSchedules,
+		event:  event.OrderRefunded,
+	},
+	constants.PartiallyRefunded: {
+		from:   []constants.OrderStatus{constants.PaymentSuccess},
+		actors: []constants.OrderActor{constants.PaymentActor},
+		effect: noScheduleEffect,
+	},
+	constants.Completed: {
+		from:   []constants.OrderStatus{constants.PaymentSuccess, constants.PartiallyRefunded},
+		actors: []constants.OrderActor{constants.AdminActor, constants.SystemActor},
+		effect: noScheduleEffect,
+	},
+	constants.NoShow: {
+		from:   []constants.OrderStatus{constants.PaymentSuccess},
+		actors: []constants.OrderActor{constants.AdminActor, constants.SystemActor},
+		effect: noScheduleEffect,
+	},
+}
+
+// Statuses in which the order still holds or books its field schedules
+var scheduleHoldingStatuses = []constants.OrderStatus{
+	constants.Pending,
+	constants.PendingPayment,
+	constants.PaymentChallenged,
+	constants.PaymentSuccess,
+	constants.PartiallyRefunded,
+}
+
+// Check whether the actor may move the order to the status
+func (o *OrderService) validateTransition(
+	order *models.Order,
+	status constants.OrderStatus,
+	actor constants.OrderActor,
+) (*orderTransition, error) {
+	rule, ok := orderTransitions[status]
+	if !ok || !slices.Contains(rule.from, order.Status) {
+		return nil, errOrder.ErrInvalidStatusTransition
+	}
+
+	if !slices.Contains(rule.actors, actor) {
+		return nil, errOrder.ErrTransitionNotAllowed
+	}
+	return &rule, nil
+}
+
+// Move the order to the new status, record the history and apply the side effect on the field schedules.
+// The other columns in changes are updated together with the status.
+func (o *OrderService) transition(
+	ctx context.Context,
+	order *models.Order,
+	status constants.OrderStatus,
+	actor constants.OrderActor,
+	changes *models.Order,
+) error {
+	rule, err := o.validateTransition(order, status, actor)
+	if err != nil {
+		return err
+	}
+
+	if changes == nil {
+		changes = &models.Order{}
+	}
+	changes.Status = status
+	from := order.Status
+
+	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		txErr := o.repository.GetOrder().UpdateStatus(ctx, tx, changes, order.UUID, from)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = o.repository.GetOrderHistory().Create(ctx, tx, &dto.OrderHistoryRequest{
+			Status:  status.GetStatusString(),
+			Actor:   actor,
+			OrderID: order.ID,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		if rule.releaseVoucher && order.VoucherID != nil {
+			txErr = o.repository.GetVoucher().Release(ctx, tx, order.ID)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		txErr = o.advanceSaga(ctx, tx, order.ID, from, status)
+		if txErr != nil {
+			return txErr
+		}
+
+		if rule.closeShares && order.ShareCount > 0 {
+			txErr = o.closeShares(ctx, tx, order, status)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		if order.PaymentID != uuid.Nil && slices.Contains(rule.closePaymentBy, actor) {
+			txErr = o.closePayment(ctx, tx, order, from, status)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		if rule.event == "" {
+			return nil
+		}
+
+		// Schedules of a cancelled or expired order may already belong to another order
+		fieldScheduleIDs := make([]uuid.UUID, 0)
+		if rule.effect != releaseSchedules || slices.Contains(scheduleHoldingStatuses, from) {
+			fieldScheduleIDs, txErr = o.findFieldScheduleIDs(ctx, order.ID)
+			if txErr != nil {
+				return txErr
+			}
+		}
+		return o.enqueueEvent(ctx, tx, rule.event, order, status, fieldScheduleIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	order.Status = status
+	logrus.Infof("order %s moved from %s to %s by %s",
+		order.UUID, from.GetStatusString(), status.GetStatusString(), actor)
+	return nil
+}
+
+// Transition triggered by an event, an event that does not fit the current status is stale and ignored
+func (o *OrderService) transitionFromEvent(
+	ctx context.Context,
+	order *models.Order,
+	status constants.OrderStatus,
+	actor constants.OrderActor,
+	changes *models.Order,
+) error {
+	err := o.transition(ctx, order, status, actor, changes)
+	if err == errOrder.ErrInvalidStatusTransition {
+		logrus.Infof("order %s is %s, ignoring transition to %s",
+			order.UUID, order.Status.GetStatusString(), status.GetStatusString())
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"order-service/constants"
+	errOrder "order-service/constants/error/order"
+	"order-service/domain/models"
+	"order-service/repositories"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    constants.OrderStatus
+		status  constants.OrderStatus
+		actor   constants.OrderActor
+		wantErr error
+	}{
+		{
+			name:   "pending to payment success by payment",
+			from:   constants.Pending,
+			status: constants.PaymentSuccess,
+			actor:  constants.PaymentActor,
+		},
+		{
+			name:   "challenged to payment success by payment",
+			from:   constants.PaymentChallenged,
+			status: constants.PaymentSuccess,
+			actor:  constants.PaymentActor,
+		},
+		{
+			name:   "pending to cancelled by customer",
+			from:   constants.Pending,
+			status: constants.Cancelled,
+			actor:  constants.CustomerActor,
+		},
+		{
+			name:   "partially refunded to cancelled by admin",
+			from:   constants.PartiallyRefunded,
+			status: constants.Cancelled,
+			actor:  constants.AdminActor,
+		},
+		{
+			name:   "cancelled to refunded by payment",
+			from:   constants.Cancelled,
+			status: constants.Refunded,
+			actor:  constants.PaymentActor,
+		},
+		{
+			name:    "payment success by customer",
+			from:    constants.Pending,
+			status:  constants.PaymentSuccess,
+			actor:   constants.CustomerActor,
+			wantErr: errOrder.ErrTransitionNotAllowed,
+		},
+		{
+			name:    "completed by payment",
+			from:    constants.PaymentSuccess,
+			status:  constants.Completed,
+			actor:   constants.PaymentActor,
+			wantErr: errOrder.ErrTransitionNotAllowed,
+		},
+		{
+			name:    "expired order paid",
+			from:    constants.Expired,
+			status:  constants.PaymentSuccess,
+			actor:   constants.PaymentActor,
+			wantErr: errOrder.ErrInvalidStatusTransition,
+		},
+		{
+			name:    "pending order completed",
+			from:    constants.Pending,
+			status:  constants.Completed,
+			actor:   constants.AdminActor,
+			wantErr: errOrder.ErrInvalidStatusTransition,
+		},
+		{
+			name:    "cancelled order cancelled again",
+			from:    constants.Cancelled,
+			status:  constants.Cancelled,
+			actor:   constants.AdminActor,
+			wantErr: errOrder.ErrInvalidStatusTransition,
+		},
+		{
+			name:    "back to pending",
+			from:    constants.PendingPayment,
+			status:  constants.Pending,
+			actor:   constants.SystemActor,
+			wantErr: errOrder.ErrInvalidStatusTransition,
+		},
+	}
+
+	service := &OrderService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := service.validateTransition(&models.Order{Status: tt.from}, tt.status, tt.actor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validateTransition() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && rule == nil {
+				t.Fatal("validateTransition() returned no rule")
+			}
+		})
+	}
+}
+
+func newMockOrderService(t *testing.T) (*OrderService, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	return &OrderService{repository: repositories.NewRepositoryRegistry(db)}, mock
+}
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+		wantStatus   constants.OrderStatus
+	}{
+		{
+			name:         "status unchanged in the database",
+			rowsAffected: 1,
+			wantStatus:   constants.PendingPayment,
+		},
+		{
+			name:         "status changed by another writer",
+			rowsAffected: 0,
+			wantErr:      errOrder.ErrInvalidStatusTransition,
+			wantStatus:   constants.Pending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockOrderService(t)
+			order := &models.Order{ID: 1, UUID: uuid.New(), Status: constants.Pending}
+
+			// The update only applies while the order still has the status it was read with
+			mock.ExpectBegin()
+			mock.ExpectExec(`UPDATE "orders" SET .+ WHERE uuid = \$\d+ AND status = \$\d+`).
+				WithArgs(constants.PendingPayment, sqlmock.AnyArg(), order.UUID, constants.Pending).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			if tt.wantErr == nil {
+				mock.ExpectQuery(`INSERT INTO "order_histories"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err := service.transition(context.Background(), order, constants.PendingPayment, constants.PaymentActor, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("transition() error = %v, want %v", err, tt.wantErr)
+			}
+			if order.Status != tt.wantStatus {
+				t.Fatalf("order status = %v, want %v", order.Status, tt.wantStatus)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestTransitionFromEventIgnoresStaleStatus(t *testing.T) {
+	service, mock := newMockOrderService(t)
+	order := &models.Order{ID: 1, UUID: uuid.New(), Status: constants.PendingPayment}
+
+	// The order was cancelled after it was read, the late expiry changes nothing
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "orders" SET .+ WHERE uuid = \$\d+ AND status = \$\d+`).
+		WithArgs(constants.Expired, sqlmock.AnyArg(), order.UUID, constants.PendingPayment).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := service.transitionFromEvent(context.Background(), order, constants.Expired, constants.PaymentActor, nil)
+	if err != nil {
+		t.Fatalf("transitionFromEvent() error = %v", err)
+	}
+	if order.Status != constants.PendingPayment {
+		t.Fatalf("order status = %v, want %v", order.Status, constants.PendingPayment)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
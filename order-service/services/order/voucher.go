@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"math"
+	clientField "order-service/clients/field"
+	clientUser "order-service/clients/user"
+	"order-service/constants"
+	errVoucher "order-service/constants/error/voucher"
+	"order-service/domain/models"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// Validate the voucher for the user and the field schedules, and compute the discount
+func (o *OrderService) applyVoucher(
+	ctx context.Context,
+	user *clientUser.UserData,
+	code string,
+	fields []clientField.FieldData,
+	subtotal float64,
+) (*models.Voucher, float64, error) {
+	voucher, err := o.repository.GetVoucher().FindByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	if now.Before(voucher.StartAt) || now.After(voucher.EndAt) {
+		return nil, 0, errVoucher.ErrVoucherNotActive
+	}
+
+	if subtotal < voucher.MinSpend {
+		return nil, 0, errVoucher.ErrVoucherMinSpendNotMet
+	}
+
+	if voucher.UsageLimit > 0 && voucher.UsedCount >= voucher.UsageLimit {
+		return nil, 0, errVoucher.ErrVoucherUsageLimitReached
+	}
+
+	if voucher.UsageLimitPerUser > 0 {
+		used, err := o.repository.GetVoucher().CountUsageByUserID(ctx, voucher.ID, user.UUID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if used >= int64(voucher.UsageLimitPerUser) {
+			return nil, 0, errVoucher.ErrVoucherUsageLimitReached
+		}
+	}
+
+	// Only the field schedules matching the restrictions are discounted
+	var (
+		eligible float64
+		fieldIDs = voucher.FieldIDList()
+		weekdays = voucher.WeekdayList()
+	)
+	for _, field := range fields {
+		if len(fieldIDs) > 0 && !slices.Contains(fieldIDs, field.FieldID.String()) {
+			continue
+		}
+		if len(weekdays) > 0 {
+			date, err := time.Parse(time.DateOnly, field.Date)
+			if err != nil || !slices.Contains(weekdays, int(date.Weekday())) {
+				continue
+			}
+		}
+		eligible += field.PricePerHour
+	}
+
+	if eligible == 0 {
+		return nil, 0, errVoucher.ErrVoucherNotApplicable
+	}
+
+	discount := voucher.Value
+	if voucher.Type == constants.PercentageVoucher {
+		discount = eligible * voucher.Value / 100
+		if voucher.MaxDiscount != nil && discount > *voucher.MaxDiscount {
+			discount = *voucher.MaxDiscount
+		}
+	}
+
+	// The payment gateway only accepts whole rupiah, and no payment of zero
+	discount = math.Min(math.Round(discount), eligible)
+	if subtotal-discount < 1 {
+		return nil, 0, errVoucher.ErrVoucherCoversWholeOrder
+	}
+	return voucher, discount, nil
+}
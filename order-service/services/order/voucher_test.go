@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	clientField "order-service/clients/field"
+	clientUser "order-service/clients/user"
+	"order-service/constants"
+	errVoucher "order-service/constants/error/voucher"
+	"order-service/domain/models"
+	"order-service/repositories"
+	voucherRepo "order-service/repositories/voucher"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type fakeRegistry struct {
+	repositories.IRepositoryRegistry
+	voucher voucherRepo.IVoucherRepository
+}
+
+func (f *fakeRegistry) GetVoucher() voucherRepo.IVoucherRepository {
+	return f.voucher
+}
+
+type fakeVoucherRepository struct {
+	voucherRepo.IVoucherRepository
+	voucher *models.Voucher
+	used    int64
+}
+
+func (f *fakeVoucherRepository) FindByCode(_ context.Context, code string) (*models.Voucher, error) {
+	if f.voucher == nil || f.voucher.Code != code {
+		return nil, errVoucher.ErrVoucherNotFound
+	}
+	return f.voucher, nil
+}
+
+func (f *fakeVoucherRepository) CountUsageByUserID(context.Context, uint, uuid.UUID) (int64, error) {
+	return f.used, nil
+}
+
+func TestApplyVoucher(t *testing.T) {
+	var (
+		now         = time.Now()
+		fieldA      = uuid.New()
+		fieldB      = uuid.New()
+		maxDiscount = 30000.0
+		onlyFieldA  = `["` + fieldA.String() + `"]`
+		onlyMonday  = `[1]`
+	)
+
+	// 2025-01-06 is a Monday and 2025-01-05 a Sunday
+	fields := []clientField.FieldData{
+		{FieldID: fieldA, PricePerHour: 100000, Date: "2025-01-06"},
+		{FieldID: fieldB, PricePerHour: 50000, Date: "2025-01-05"},
+	}
+
+	newVoucher := func(modify func(*models.Voucher)) *models.Voucher {
+		voucher := &models.Voucher{
+			ID:      1,
+			Code:    "HEMAT",
+			Type:    constants.FixedVoucher,
+			Value:   20000,
+			StartAt: now.Add(-time.Hour),
+			EndAt:   now.Add(time.Hour),
+		}
+		if modify != nil {
+			modify(voucher)
+		}
+		return voucher
+	}
+
+	tests := []struct {
+		name         string
+		code         string
+		voucher      *models.Voucher
+		used         int64
+		fields       []clientField.FieldData
+		subtotal     float64
+		wantDiscount float64
+		wantErr      error
+	}{
+		{
+			name:         "fixed",
+			code:         " hemat ",
+			voucher:      newVoucher(nil),
+			fields:       fields,
+			subtotal:     150000,
+			wantDiscount: 20000,
+		},
+		{
+			name: "percentage",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.Type = constants.PercentageVoucher
+				v.Value = 10
+			}),
+			fields:       fields,
+			subtotal:     150000,
+			wantDiscount: 15000,
+		},
+		{
+			name: "percentage capped by max discount",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.Type = constants.PercentageVoucher
+				v.Value = 50
+				v.MaxDiscount = &maxDiscount
+			}),
+			fields:       fields,
+			subtotal:     150000,
+			wantDiscount: 30000,
+		},
+		{
+			name: "percentage rounded to whole rupiah",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.Type = constants.PercentageVoucher
+				v.Value = 12.5
+			}),
+			fields:       []clientField.FieldData{{FieldID: fieldA, PricePerHour: 99999, Date: "2025-01-06"}},
+			subtotal:     99999,
+			wantDiscount: 12500,
+		},
+		{
+			name: "restricted to a field",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.Type = constants.PercentageVoucher
+				v.Value = 10
+				v.FieldIDs = &onlyFieldA
+			}),
+			fields:       fields,
+			subtotal:     150000,
+			wantDiscount: 10000,
+		},
+		{
+			name: "restricted to a weekday",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.Value = 80000
+				v.Weekdays = &onlyMonday
+			}),
+			fields:       fields,
+			subtotal:     150000,
+			wantDiscount: 80000,
+		},
+		{
+			name: "capped at the eligible amount",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.Value = 80000
+				v.FieldIDs = &onlyFieldA
+			}),
+			fields:       []clientField.FieldData{{FieldID: fieldA, PricePerHour: 50000}, {FieldID: fieldB, PricePerHour: 50000}},
+			subtotal:     100000,
+			wantDiscount: 50000,
+		},
+		{
+			name:     "not found",
+			code:     "LAINNYA",
+			voucher:  newVoucher(nil),
+			fields:   fields,
+			subtotal: 150000,
+			wantErr:  errVoucher.ErrVoucherNotFound,
+		},
+		{
+			name: "not started",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.StartAt = now.Add(time.Hour)
+				v.EndAt = now.Add(2 * time.Hour)
+			}),
+			fields:   fields,
+			subtotal: 150000,
+			wantErr:  errVoucher.ErrVoucherNotActive,
+		},
+		{
+			name: "ended",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.EndAt = now.Add(-time.Minute)
+			}),
+			fields:   fields,
+			subtotal: 150000,
+			wantErr:  errVoucher.ErrVoucherNotActive,
+		},
+		{
+			name: "min spend not met",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.MinSpend = 200000
+			}),
+			fields:   fields,
+			subtotal: 150000,
+			wantErr:  errVoucher.ErrVoucherMinSpendNotMet,
+		},
+		{
+			name: "usage limit reached",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.UsageLimit = 10
+				v.UsedCount = 10
+			}),
+			fields:   fields,
+			subtotal: 150000,
+			wantErr:  errVoucher.ErrVoucherUsageLimitReached,
+		},
+		{
+			name: "usage limit per user reached",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.UsageLimitPerUser = 1
+			}),
+			used:     1,
+			fields:   fields,
+			subtotal: 150000,
+			wantErr:  errVoucher.ErrVoucherUsageLimitReached,
+		},
+		{
+			name: "usage limit per user not reached",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.UsageLimitPerUser = 2
+			}),
+			used:         1,
+			fields:       fields,
+			subtotal:     150000,
+			wantDiscount: 20000,
+		},
+		{
+			name: "no eligible field",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.FieldIDs = &onlyFieldA
+			}),
+			fields:   fields[1:],
+			subtotal: 50000,
+			wantErr:  errVoucher.ErrVoucherNotApplicable,
+		},
+		{
+			name: "no eligible weekday",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.Weekdays = &onlyMonday
+			}),
+			fields:   fields[1:],
+			subtotal: 50000,
+			wantErr:  errVoucher.ErrVoucherNotApplicable,
+		},
+		{
+			name: "covers the whole order",
+			code: "HEMAT",
+			voucher: newVoucher(func(v *models.Voucher) {
+				v.Value = 150000
+			}),
+			fields:   fields,
+			subtotal: 150000,
+			wantErr:  errVoucher.ErrVoucherCoversWholeOrder,
+		},
+	}
+
+	user := &clientUser.UserData{UUID: uuid.New()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &OrderService{
+				repository: &fakeRegistry{
+					voucher: &fakeVoucherRepository{voucher: tt.voucher, used: tt.used},
+				},
+			}
+
+			voucher, discount, err := service.applyVoucher(context.Background(), user, tt.code, tt.fields, tt.subtotal)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("applyVoucher() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if voucher != tt.voucher {
+				t.Fatalf("applyVoucher() voucher = %v, want %v", voucher, tt.voucher)
+			}
+			if discount != tt.wantDiscount {
+				t.Fatalf("applyVoucher() discount = %v, want %v", discount, tt.wantDiscount)
+			}
+		})
+	}
+}
@@ -1,24 +1,36 @@
 package services
 
 import (
+	"common/kafka"
 	"order-service/clients"
 	"order-service/repositories"
 	services "order-service/services/order"
+	voucherServices "order-service/services/voucher"
 )
 
 type Registry struct {
 	repository repositories.IRepositoryRegistry
 	client     clients.IClientRegistry
+	producer   kafka.IProducer
 }
 
 type IServiceRegistry interface {
 	GetOrder() services.IOrderService
+	GetVoucher() voucherServices.IVoucherService
 }
 
-func NewServiceRegistry(repository repositories.IRepositoryRegistry, client clients.IClientRegistry) IServiceRegistry {
-	return &Registry{repository: repository, client: client}
+func NewServiceRegistry(
+	repository repositories.IRepositoryRegistry,
+	client clients.IClientRegistry,
+	producer kafka.IProducer,
+) IServiceRegistry {
+	return &Registry{repository: repository, client: client, producer: producer}
 }
 
 func (r *Registry) GetOrder() services.IOrderService {
-	return services.NewOrderService(r.repository, r.client)
+	return services.NewOrderService(r.repository, r.client, r.producer)
+}
+
+func (r *Registry) GetVoucher() voucherServices.IVoucherService {
+	return voucherServices.NewVoucherService(r.repository)
 }
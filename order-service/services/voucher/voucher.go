@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"order-service/constants"
+	errVoucher "order-service/constants/error/voucher"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	"order-service/repositories"
+	"strings"
+)
+
+type VoucherService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IVoucherService interface {
+	GetAll(context.Context) ([]dto.VoucherResponse, error)
+	Create(context.Context, *dto.VoucherRequest) (*dto.VoucherResponse, error)
+}
+
+func NewVoucherService(repository repositories.IRepositoryRegistry) IVoucherService {
+	return &VoucherService{repository: repository}
+}
+
+func (v *VoucherService) toVoucherResponse(voucher *models.Voucher) dto.VoucherResponse {
+	return dto.VoucherResponse{
+		UUID:              voucher.UUID,
+		Code:              voucher.Code,
+		Type:              voucher.Type,
+		Value:             voucher.Value,
+		MaxDiscount:       voucher.MaxDiscount,
+		MinSpend:          voucher.MinSpend,
+		StartAt:           voucher.StartAt,
+		EndAt:             voucher.EndAt,
+		UsageLimit:        voucher.UsageLimit,
+		UsageLimitPerUser: voucher.UsageLimitPerUser,
+		UsedCount:         voucher.UsedCount,
+		FieldIDs:          voucher.FieldIDList(),
+		Weekdays:          voucher.WeekdayList(),
+		CreatedAt:         voucher.CreatedAt,
+		UpdatedAt:         voucher.UpdatedAt,
+	}
+}
+
+// Get All
+func (v *VoucherService) GetAll(ctx context.Context) ([]dto.VoucherResponse, error) {
+	vouchers, err := v.repository.GetVoucher().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.VoucherResponse, 0, len(vouchers))
+	for _, voucher := range vouchers {
+		response = append(response, v.toVoucherResponse(&voucher))
+	}
+	return response, nil
+}
+
+// Create
+func (v *VoucherService) Create(ctx context.Context, request *dto.VoucherRequest) (*dto.VoucherResponse, error) {
+	if !request.EndAt.After(request.StartAt) {
+		return nil, errVoucher.ErrInvalidVoucherPeriod
+	}
+
+	// A percentage above 100 would make the order negative
+	if request.Type == constants.PercentageVoucher && request.Value > 100 {
+		return nil, errVoucher.ErrInvalidVoucherValue
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(request.Code))
+	existing, err := v.repository.GetVoucher().FindByCode(ctx, code)
+	if err != nil && err != errVoucher.ErrVoucherNotFound {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errVoucher.ErrVoucherAlreadyExist
+	}
+
+	voucher := &models.Voucher{
+		Code:              code,
+		Type:              request.Type,
+		Value:             request.Value,
+		MaxDiscount:       request.MaxDiscount,
+		MinSpend:          request.MinSpend,
+		StartAt:           request.StartAt,
+		EndAt:             request.EndAt,
+		UsageLimit:        request.UsageLimit,
+		UsageLimitPerUser: request.UsageLimitPerUser,
+	}
+
+	if len(request.FieldIDs) > 0 {
+		fieldIDs, err := json.Marshal(request.FieldIDs)
+		if err != nil {
+			return nil, err
+		}
+		restriction := string(fieldIDs)
+		voucher.FieldIDs = &restriction
+	}
+
+	if len(request.Weekdays) > 0 {
+		weekdays, err := json.Marshal(request.Weekdays)
+		if err != nil {
+			return nil, err
+		}
+		restriction := string(weekdays)
+		voucher.Weekdays = &restriction
+	}
+
+	voucher, err = v.repository.GetVoucher().Create(ctx, voucher)
+	if err != nil {
+		return nil, err
+	}
+
+	response := v.toVoucherResponse(voucher)
+	return &response, nil
+}
@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"context"
+	"order-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ExpiryWorker struct {
+	service  services.IServiceRegistry
+	interval time.Duration
+}
+
+type IExpiryWorker interface {
+	Start(context.Context)
+}
+
+func NewExpiryWorker(service services.IServiceRegistry, interval time.Duration) IExpiryWorker {
+	return &ExpiryWorker{service: service, interval: interval}
+}
+
+// Sweep the pending orders whose payment has expired
+func (e *ExpiryWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	logrus.Infof("expiry worker started, interval %s", e.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Infof("expiry worker stopped")
+			return
+		case <-ticker.C:
+			err := e.service.GetOrder().ExpireOrders(ctx)
+			if err != nil {
+				logrus.Errorf("failed to expire orders: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package workers
+
+import (
+	"order-service/config"
+	"order-service/services"
+	expiryWorker "order-service/workers/expiry"
+	outboxWorker "order-service/workers/outbox"
+	"time"
+)
+
+type Registry struct {
+	service services.IServiceRegistry
+}
+
+type IWorkerRegistry interface {
+	GetExpiry() expiryWorker.IExpiryWorker
+	GetOutbox() outboxWorker.IOutboxWorker
+}
+
+func NewWorkerRegistry(service services.IServiceRegistry) IWorkerRegistry {
+	return &Registry{service: service}
+}
+
+func (r *Registry) GetExpiry() expiryWorker.IExpiryWorker {
+	interval := time.Duration(config.Config.Worker.ExpirySweeperIntervalInSeconds) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return expiryWorker.NewExpiryWorker(r.service, interval)
+}
+
+func (r *Registry) GetOutbox() outboxWorker.IOutboxWorker {
+	interval := time.Duration(config.Config.Worker.OutboxRelayIntervalInSeconds) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return outboxWorker.NewOutboxWorker(r.service, interval)
+}
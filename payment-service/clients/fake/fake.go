@@ -0,0 +1,330 @@
+package clients
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	gateway "payment-service/clients/gateway"
+	"payment-service/constants"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Offline payment gateway for local development. It keeps the transactions in memory,
+// serves a checkout page and sends Midtrans-like signed notifications to the webhook.
+type FakeGateway struct {
+	baseURL      string
+	serverKey    string
+	mutex        sync.Mutex
+	transactions map[string]*fakeTransaction
+}
+
+type fakeTransaction struct {
+	orderID       string
+	transactionID string
+	amount        float64
+	refundAmount  float64
+	description   string
+	status        string
+	expiredAt     time.Time
+	settledAt     *time.Time
+	isNotified    bool
+}
+
+type IFakeGateway interface {
+	gateway.IPaymentGateway
+	Serve(*gin.Engine)
+}
+
+// Midtrans transaction status and the status code it is notified with
+var fakeStatusCodes = map[string]string{
+	"pending":        "201",
+	"settlement":     "200",
+	"deny":           "202",
+	"cancel":         "200",
+	"expire":         "407",
+	"refund":         "200",
+	"partial_refund": "200",
+}
+
+func NewFakeGateway(baseURL, serverKey string) IFakeGateway {
+	return &FakeGateway{
+		baseURL:      baseURL,
+		serverKey:    serverKey,
+		transactions: make(map[string]*fakeTransaction),
+	}
+}
+
+func (f *FakeGateway) CreatePaymentLink(request *dto.PaymentRequest) (*gateway.PaymentLink, error) {
+	if !request.ExpiredAt.After(time.Now()) {
+		return nil, errPayment.ErrExpireAtInvalid
+	}
+
+	var description string
+	if request.Description != nil {
+		description = *request.Description
+	}
+
+	f.mutex.Lock()
+	f.transactions[request.OrderID] = &fakeTransaction{
+		orderID:       request.OrderID,
+		transactionID: uuid.New().String(),
+		amount:        request.Amount,
+		description:   description,
+		status:        "pending",
+		expiredAt:     request.ExpiredAt,
+	}
+	f.mutex.Unlock()
+
+	return &gateway.PaymentLink{
+		Token:       uuid.New().String(),
+		RedirectURL: fmt.Sprintf("%s/fake-gateway/checkout/%s", f.baseURL, request.OrderID),
+	}, nil
+}
+
+func (f *FakeGateway) GetStatus(orderID string) (*dto.Webhook, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	transaction, ok := f.transactions[orderID]
+	if !ok {
+		return nil, errPayment.ErrPaymentNotFound
+	}
+	f.expireIfDue(transaction)
+	return f.notification(transaction)
+}
+
+func (f *FakeGateway) ExpireTransaction(orderID string) error {
+	return f.transition(orderID, "expire", "pending")
+}
+
+func (f *FakeGateway) CancelTransaction(orderID string) error {
+	return f.transition(orderID, "cancel", "pending", "settlement")
+}
+
+func (f *FakeGateway) RefundTransaction(orderID string, request *gateway.RefundRequest) error {
+	f.mutex.Lock()
+	transaction, ok := f.transactions[orderID]
+	if !ok {
+		f.mutex.Unlock()
+		return errPayment.ErrPaymentNotFound
+	}
+	if transaction.status != "settlement" && transaction.status != "partial_refund" {
+		f.mutex.Unlock()
+		return fmt.Errorf("fake gateway: transaction %s can not be refunded", orderID)
+	}
+
+	transaction.refundAmount += request.Amount
+	transaction.status = "partial_refund"
+	if transaction.refundAmount >= transaction.amount {
+		transaction.status = "refund"
+	}
+	notification, err := f.notification(transaction)
+	f.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	go f.sendNotification(notification)
+	return nil
+}
+
+// Register the checkout page routes
+func (f *FakeGateway) Serve(router *gin.Engine) {
+	group := router.Group("/fake-gateway/checkout")
+	group.GET("/:orderID", f.checkout)
+	group.POST("/:orderID/pay", f.action("settlement"))
+	group.POST("/:orderID/deny", f.action("deny"))
+	group.POST("/:orderID/cancel", f.action("cancel"))
+	logrus.Infof("fake payment gateway is enabled, checkout page on %s/fake-gateway/checkout", f.baseURL)
+}
+
+func (f *FakeGateway) checkout(c *gin.Context) {
+	orderID := c.Param("orderID")
+
+	f.mutex.Lock()
+	transaction, ok := f.transactions[orderID]
+	if !ok {
+		f.mutex.Unlock()
+		c.String(http.StatusNotFound, errPayment.ErrPaymentNotFound.Error())
+		return
+	}
+	f.expireIfDue(transaction)
+	isFirstVisit := !transaction.isNotified
+	transaction.isNotified = true
+	data := gin.H{
+		"OrderID":     transaction.orderID,
+		"Description": transaction.description,
+		"Amount":      fmt.Sprintf("%.2f", transaction.amount),
+		"Status":      transaction.status,
+		"ExpiredAt":   transaction.expiredAt.Format(time.RFC1123),
+		"IsPending":   transaction.status == "pending",
+	}
+	notification, err := f.notification(transaction)
+	f.mutex.Unlock()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Midtrans notifies pending once the customer opens the payment page
+	if isFirstVisit && notification.TransactionStatus == "pending" {
+		go f.sendNotification(notification)
+	}
+
+	var page bytes.Buffer
+	err = checkoutTemplate.Execute(&page, data)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+func (f *FakeGateway) action(status string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orderID := c.Param("orderID")
+		err := f.transition(orderID, status, "pending")
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.Redirect(http.StatusSeeOther, fmt.Sprintf("/fake-gateway/checkout/%s", orderID))
+	}
+}
+
+// Move the transaction to the new status and notify the webhook
+func (f *FakeGateway) transition(orderID, status string, from ...string) error {
+	f.mutex.Lock()
+	transaction, ok := f.transactions[orderID]
+	if !ok {
+		f.mutex.Unlock()
+		return errPayment.ErrPaymentNotFound
+	}
+	f.expireIfDue(transaction)
+
+	allowed := false
+	for _, item := range from {
+		if transaction.status == item {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		f.mutex.Unlock()
+		return fmt.Errorf("fake gateway: transaction %s is already %s", orderID, transaction.status)
+	}
+
+	transaction.status = status
+	if status == "settlement" {
+		now := time.Now()
+		transaction.settledAt = &now
+	}
+	notification, err := f.notification(transaction)
+	f.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	go f.sendNotification(notification)
+	return nil
+}
+
+func (f *FakeGateway) expireIfDue(transaction *fakeTransaction) {
+	if transaction.status == "pending" && time.Now().After(transaction.expiredAt) {
+		transaction.status = "expire"
+	}
+}
+
+// Build the notification body the same way midtrans does, including the signature key
+func (f *FakeGateway) notification(transaction *fakeTransaction) (*dto.Webhook, error) {
+	orderID, err := uuid.Parse(transaction.orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	statusCode := fakeStatusCodes[transaction.status]
+	grossAmount := fmt.Sprintf("%.2f", transaction.amount)
+	hash := sha512.Sum512([]byte(fmt.Sprintf("%s%s%s%s", transaction.orderID, statusCode, grossAmount, f.serverKey)))
+
+	var settlementTime string
+	if transaction.settledAt != nil {
+		settlementTime = transaction.settledAt.Format(time.DateTime)
+	}
+
+	acquirer := "fake"
+	return &dto.Webhook{
+		VANumbers: []dto.VANumber{
+			{
+				VaNumber: fmt.Sprintf("8808%s", transaction.transactionID[:8]),
+				Bank:     "fake",
+			},
+		},
+		TransactionTime:   time.Now().Format(time.DateTime),
+		TransactionStatus: constants.PaymentStatusString(transaction.status),
+		TransactionID:     transaction.transactionID,
+		StatusMessage:     "fake gateway notification",
+		StatusCode:        statusCode,
+		SignatureKey:      hex.EncodeToString(hash[:]),
+		SettlementTime:    settlementTime,
+		PaymentType:       "bank_transfer",
+		OrderID:           orderID,
+		MerchantID:        "FAKE",
+		GrossAmount:       grossAmount,
+		FraudStatus:       "accept",
+		Currency:          "IDR",
+		Acquirer:          &acquirer,
+	}, nil
+}
+
+func (f *FakeGateway) sendNotification(notification *dto.Webhook) {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		logrus.Errorf("fake gateway: failed to marshal notification: %v", err)
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/v1/payment/webhook", f.baseURL)
+	res, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		logrus.Errorf("fake gateway: failed to send notification: %v", err)
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		logrus.Warnf("fake gateway: webhook responded %d for order %s", res.StatusCode, notification.OrderID)
+	}
+}
+
+var checkoutTemplate = template.Must(template.New("checkout").Parse(`<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="utf-8">
+	<title>Fake Payment Gateway</title>
+</head>
+<body>
+	<h1>Fake Payment Gateway</h1>
+	<p>Order: {{.OrderID}}</p>
+	<p>Description: {{.Description}}</p>
+	<p>Amount: IDR {{.Amount}}</p>
+	<p>Status: <strong>{{.Status}}</strong></p>
+	<p>Expired at: {{.ExpiredAt}}</p>
+	{{if .IsPending}}
+	<form method="post" action="/fake-gateway/checkout/{{.OrderID}}/pay"><button type="submit">Pay</button></form>
+	<form method="post" action="/fake-gateway/checkout/{{.OrderID}}/deny"><button type="submit">Deny</button></form>
+	<form method="post" action="/fake-gateway/checkout/{{.OrderID}}/cancel"><button type="submit">Cancel</button></form>
+	{{end}}
+</body>
+</html>
+`))
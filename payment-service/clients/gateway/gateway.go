@@ -0,0 +1,23 @@
+package clients
+
+import "payment-service/domain/dto"
+
+type PaymentLink struct {
+	Token       string `json:"token"`
+	RedirectURL string `json:"redirect_url"`
+}
+
+type RefundRequest struct {
+	RefundKey string
+	Amount    float64
+	Reason    string
+}
+
+// Payment gateway used by payment-service (midtrans, or the fake one for local development)
+type IPaymentGateway interface {
+	CreatePaymentLink(request *dto.PaymentRequest) (*PaymentLink, error)
+	GetStatus(orderID string) (*dto.Webhook, error)
+	ExpireTransaction(orderID string) error
+	CancelTransaction(orderID string) error
+	RefundTransaction(orderID string, request *RefundRequest) error
+}
@@ -1,11 +1,16 @@
 package clients
 
 import (
+	"math"
+	gateway "payment-service/clients/gateway"
+	"payment-service/constants"
 	errConstant "payment-service/constants/error/payment"
 	"payment-service/domain/dto"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/midtrans/midtrans-go"
+	"github.com/midtrans/midtrans-go/coreapi"
 	"github.com/midtrans/midtrans-go/snap"
 	"github.com/sirupsen/logrus"
 )
@@ -15,18 +20,14 @@ type MidtransClient struct {
 	IsProduction bool
 }
 
-type IMidtransClient interface {
-	CreatePaymentLink(request *dto.PaymentRequest) (*MidtransData, error)
-}
-
-func NewMidtransClient(serverKey string, isProduction bool) *MidtransClient {
+func NewMidtransClient(serverKey string, isProduction bool) gateway.IPaymentGateway {
 	return &MidtransClient{
 		ServerKey:    serverKey,
 		IsProduction: isProduction,
 	}
 }
 
-func (c *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*MidtransData, error) {
+func (c *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*gateway.PaymentLink, error) {
 	var (
 		snapClient   snap.Client
 		isProduction = midtrans.Sandbox
@@ -56,6 +57,17 @@ func (c *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*Midtra
 		isProduction = midtrans.Production
 	}
 
+	// Discounts are sent as negative items so the items add up to the gross amount
+	items := make([]midtrans.ItemDetails, 0, len(request.ItemDetails))
+	for _, item := range request.ItemDetails {
+		items = append(items, midtrans.ItemDetails{
+			ID:    item.ID,
+			Price: int64(item.Amount),
+			Qty:   int32(item.Quantity),
+			Name:  item.Name,
+		})
+	}
+
 	// Midtrans snap init
 	snapClient.New(c.ServerKey, isProduction)
 	req := &snap.Request{
@@ -68,14 +80,7 @@ func (c *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*Midtra
 			Email: request.CustomerDetail.Email,
 			Phone: request.CustomerDetail.Phone,
 		},
-		Items: &[]midtrans.ItemDetails{
-			{
-				ID:    request.ItemDetails[0].ID,
-				Price: int64(request.ItemDetails[0].Amount),
-				Qty:   int32(request.ItemDetails[0].Quantity),
-				Name:  request.ItemDetails[0].Name,
-			},
-		},
+		Items: &items,
 		Expiry: &snap.ExpiryDetails{
 			Unit:     expiryUnit,
 			Duration: expiryDuration,
@@ -89,8 +94,105 @@ func (c *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*Midtra
 		return nil, err
 	}
 
-	return &MidtransData{
+	return &gateway.PaymentLink{
 		RedirectURL: response.RedirectURL,
 		Token:       response.Token,
 	}, nil
 }
+
+func (c *MidtransClient) coreAPIClient() coreapi.Client {
+	var (
+		coreClient   coreapi.Client
+		isProduction = midtrans.Sandbox
+	)
+
+	if c.IsProduction {
+		isProduction = midtrans.Production
+	}
+
+	coreClient.New(c.ServerKey, isProduction)
+	return coreClient
+}
+
+// Expire the pending transaction, so the payment link can not be paid anymore
+func (c *MidtransClient) ExpireTransaction(orderID string) error {
+	coreClient := c.coreAPIClient()
+	_, err := coreClient.ExpireTransaction(orderID)
+	if err != nil {
+		logrus.Errorf("Error expire transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Cancel the transaction (only allowed by midtrans before it is settled)
+func (c *MidtransClient) CancelTransaction(orderID string) error {
+	coreClient := c.coreAPIClient()
+	_, err := coreClient.CancelTransaction(orderID)
+	if err != nil {
+		logrus.Errorf("Error cancel transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Query the transaction status, mapped to the same shape as the webhook notification
+func (c *MidtransClient) GetStatus(orderID string) (*dto.Webhook, error) {
+	coreClient := c.coreAPIClient()
+	response, err := coreClient.CheckTransaction(orderID)
+	if err != nil {
+		logrus.Errorf("Error check transaction: %v", err)
+		return nil, err
+	}
+
+	parsedOrderID, parseErr := uuid.Parse(response.OrderID)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	vaNumbers := make([]dto.VANumber, 0, len(response.VaNumbers))
+	for _, item := range response.VaNumbers {
+		vaNumbers = append(vaNumbers, dto.VANumber{
+			VaNumber: item.VANumber,
+			Bank:     item.Bank,
+		})
+	}
+
+	var acquirer *string
+	if response.Acquirer != "" {
+		acquirer = &response.Acquirer
+	}
+
+	return &dto.Webhook{
+		VANumbers:         vaNumbers,
+		TransactionTime:   response.TransactionTime,
+		TransactionStatus: constants.PaymentStatusString(response.TransactionStatus),
+		TransactionID:     response.TransactionID,
+		StatusMessage:     response.StatusMessage,
+		StatusCode:        response.StatusCode,
+		SignatureKey:      response.SignatureKey,
+		SettlementTime:    response.SettlementTime,
+		PaymentType:       response.PaymentType,
+		OrderID:           parsedOrderID,
+		MerchantID:        response.MerchantID,
+		GrossAmount:       response.GrossAmount,
+		FraudStatus:       response.FraudStatus,
+		Currency:          response.Currency,
+		Acquirer:          acquirer,
+	}, nil
+}
+
+// Refund the settled transaction, a partial refund when the amount is less than the gross amount
+func (c *MidtransClient) RefundTransaction(orderID string, request *gateway.RefundRequest) error {
+	coreClient := c.coreAPIClient()
+	_, err := coreClient.RefundTransaction(orderID, &coreapi.RefundReq{
+		RefundKey: request.RefundKey,
+		Amount:    int64(math.Round(request.Amount)),
+		Reason:    request.Reason,
+	})
+	if err != nil {
+		logrus.Errorf("Error refund transaction: %v", err)
+		return err
+	}
+	return nil
+}
@@ -1,9 +1,14 @@
 package clients
 
 import (
+	"fmt"
 	"payment-service/clients/config"
+	fakeClient "payment-service/clients/fake"
+	gatewayClient "payment-service/clients/gateway"
+	midtransClient "payment-service/clients/midtrans"
 	clients "payment-service/clients/user"
 	paymentConfig "payment-service/config"
+	"payment-service/constants"
 )
 
 type ClientRegistry struct{}
@@ -23,3 +28,26 @@ func (c *ClientRegistry) GetUser() clients.IUserClient {
 			config.WithSignatureKey(paymentConfig.Config.InternalService.User.SignatureKey),
 		))
 }
+
+// Choose the payment gateway from the config, midtrans by default
+func NewPaymentGateway() gatewayClient.IPaymentGateway {
+	switch paymentConfig.Config.PaymentGateway {
+	case constants.FakeGateway:
+		// The fake gateway settles any payment, it must never run outside of local development
+		appEnv := paymentConfig.Config.AppEnv
+		if appEnv != constants.LocalEnv && appEnv != constants.DevelopmentEnv {
+			panic(fmt.Sprintf("fake payment gateway is not allowed in the %q environment", appEnv))
+		}
+
+		baseURL := paymentConfig.Config.FakeGateway.BaseURL
+		if baseURL == "" {
+			baseURL = fmt.Sprintf("http://localhost:%d", paymentConfig.Config.Port)
+		}
+		return fakeClient.NewFakeGateway(baseURL, paymentConfig.Config.Midtrans.ServerKey)
+	default:
+		return midtransClient.NewMidtransClient(
+			paymentConfig.Config.Midtrans.ServerKey,
+			paymentConfig.Config.Midtrans.IsProduction,
+		)
+	}
+}
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"encoding/base64"
+	"expvar"
 	"fmt"
 	"net/http"
 	"payment-service/clients"
-	midtransClient "payment-service/clients/midtrans"
+	fakeClient "payment-service/clients/fake"
+	gatewayClient "payment-service/clients/gateway"
 	"payment-service/common/gcs"
 	"payment-service/common/response"
 	"payment-service/config"
@@ -17,6 +20,7 @@ import (
 	"payment-service/repositories"
 	"payment-service/routes"
 	"payment-service/services"
+	"payment-service/workers"
 	"time"
 
 	"github.com/didip/tollbooth"
@@ -30,52 +34,21 @@ var command = &cobra.Command{
 	Use:   "serve",
 	Short: "Start the server",
 	Run: func(c *cobra.Command, args []string) {
-		//	Get config from .env file
-		_ = godotenv.Load()
-
-		//	Config setup
-		config.Init()
-
-		//	Connect to DB
-		db, err := config.InitDatabase()
-		if err != nil {
-			panic(err)
-		}
+		service, gateway, client := initApp()
+		controller := controllers.NewControllerRegistry(service)
 
-		//	Set TimeZone
-		loc, err := time.LoadLocation("Asia/Jakarta")
-		if err != nil {
-			panic(err)
-		}
-		time.Local = loc
+		// Reconcile the pending payments and publish the payment events in the background
+		worker := workers.NewWorkerRegistry(service)
+		go worker.GetReconciler().Start(context.Background())
+		go worker.GetOutbox().Start(context.Background())
 
-		//	GORM will automaticaly create new table if empty
-		err = db.AutoMigrate(
-			&models.Payment{},
-			&models.PaymentHistory{},
-		)
+		// Setup gin router
+		router := gin.Default()
+		// Forwarded headers are only trusted from the proxies in front of the service
+		err := router.SetTrustedProxies(config.Config.TrustedProxies)
 		if err != nil {
 			panic(err)
 		}
-
-		// Register MessageBroker (kafka)
-		kafka := kafkaClient.NewKafkaRegistry(config.Config.Kafka.Brokers)
-
-		// Register Midtrans
-		midtrans := midtransClient.NewMidtransClient(
-			config.Config.Midtrans.ServerKey,
-			config.Config.Midtrans.IsProduction,
-		)
-
-		//	Google Cloud Service Init
-		gcs := initGCS()
-		client := clients.NewClientRegistry()
-		repository := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repository, gcs, kafka, midtrans)
-		controller := controllers.NewControllerRegistry(service)
-
-		// Setup gin router
-		router := gin.Default()
 		router.Use(middlewares.HandlePanic())
 		router.NoRoute(func(c *gin.Context) {
 			c.JSON(http.StatusNotFound, response.Response{
@@ -108,6 +81,14 @@ var command = &cobra.Command{
 			})
 		router.Use(middlewares.RateLimiter(lmt))
 
+		// Serve the checkout page of the fake gateway
+		if fakeGateway, ok := gateway.(fakeClient.IFakeGateway); ok {
+			fakeGateway.Serve(router)
+		}
+
+		// Expose the expvar counters to internal services only
+		router.GET("/debug/vars", middlewares.AuthenticateWithoutToken(), gin.WrapH(expvar.Handler()))
+
 		// Register all endpoint routes
 		group := router.Group("/api/v1")
 		route := routes.NewRouteRegistry(controller, group, client)
@@ -118,6 +99,53 @@ var command = &cobra.Command{
 	},
 }
 
+// Setup config, database, message broker and the payment gateway
+func initApp() (services.IServiceRegistry, gatewayClient.IPaymentGateway, clients.IClientRegistry) {
+	//	Get config from .env file
+	_ = godotenv.Load()
+
+	//	Config setup
+	config.Init()
+
+	//	Connect to DB
+	db, err := config.InitDatabase()
+	if err != nil {
+		panic(err)
+	}
+
+	//	Set TimeZone
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		panic(err)
+	}
+	time.Local = loc
+
+	//	GORM will automaticaly create new table if empty
+	err = db.AutoMigrate(
+		&models.Payment{},
+		&models.PaymentHistory{},
+		&models.IdempotencyKey{},
+		&models.Refund{},
+		&models.PaymentOutbox{},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Register MessageBroker (kafka)
+	kafka := kafkaClient.NewKafkaRegistry(config.Config.Kafka.Brokers)
+
+	// Register the payment gateway (midtrans or the fake one)
+	gateway := clients.NewPaymentGateway()
+
+	//	Google Cloud Service Init
+	gcs := initGCS()
+	client := clients.NewClientRegistry()
+	repository := repositories.NewRepositoryRegistry(db)
+	service := services.NewServiceRegistry(repository, gcs, kafka, gateway)
+	return service, gateway, client
+}
+
 func Run() {
 	err := command.Execute()
 	if err != nil {
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var reconcileCommand = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Reconcile the pending payments with the payment gateway once",
+	Run: func(c *cobra.Command, args []string) {
+		service, _, _ := initApp()
+
+		report, err := service.GetPayment().Reconcile(context.Background())
+		if err != nil {
+			logrus.Fatalf("failed to reconcile payments: %v", err)
+		}
+
+		for _, item := range report.Discrepancies {
+			logrus.Infof("payment %s (order %s): %s -> %s, applied %t %s",
+				item.PaymentID, item.OrderID, item.LocalStatus, item.GatewayStatus, item.Applied, item.Error)
+		}
+	},
+}
+
+func init() {
+	command.AddCommand(reconcileCommand)
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import "expvar"
+
+// Counters exposed on /debug/vars
+var (
+	WebhookRejected = expvar.NewMap("webhookRejected")
+)
+
+const (
+	WebhookRejectedSignature = "signature"
+	WebhookRejectedIP        = "ip"
+)
+
+// Payment outbox, the lag is the age of the oldest event that is not published yet
+var (
+	PaymentOutboxPending    = expvar.NewInt("paymentOutboxPending")
+	PaymentOutboxLagSeconds = expvar.NewFloat("paymentOutboxLagSeconds")
+	PaymentOutboxRelayed    = expvar.NewMap("paymentOutboxRelayed")
+)
+
+const (
+	PaymentOutboxPublished = "published"
+	PaymentOutboxFailed    = "failed"
+)
@@ -11,28 +11,35 @@ import (
 var Config AppConfig
 
 type AppConfig struct {
-	Port                       int             `json:"port"`
-	AppName                    string          `json:"appName"`
-	AppEnv                     string          `json:"appEnv"`
-	SignatureKey               string          `json:"signatureKey"`
-	Database                   Database        `json:"database"`
-	RateLimiterMaxRequest      float64         `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond      int             `json:"rateLimiterTimeSecond"`
-	InternalService            InternalService `json:"internalService"`
-	GCSType                    string          `json:"gcsType"`
-	GCSProjectID               string          `json:"gcsProjectID"`
-	GCSPrivateKeyID            string          `json:"gcsPrivateKeyID"`
-	GCSPrivateKey              string          `json:"gcsPrivateKey"`
-	GCSClientEmail             string          `json:"gcsClientEmail"`
-	GCSClientID                string          `json:"gcsClientID"`
-	GCSAuthURI                 string          `json:"gcsAuthURI"`
-	GCSTokenURI                string          `json:"gcsTokenURI"`
-	GCSAuthProviderX509CertURL string          `json:"gcsAuthProviderX509CertURL"`
-	GCSClientX509CertURL       string          `json:"gcsClientX509CertURL"`
-	GCSUniverseDomain          string          `json:"gcsUniverseDomain"`
-	GCSBucketName              string          `json:"gcsBucketName"`
-	Kafka                      Kafka           `json:"kafka"`
-	Midtrans                   Midtrans        `json:"midtrans"`
+	Port                       int               `json:"port"`
+	AppName                    string            `json:"appName"`
+	AppEnv                     string            `json:"appEnv"`
+	SignatureKey               string            `json:"signatureKey"`
+	Database                   Database          `json:"database"`
+	RateLimiterMaxRequest      float64           `json:"rateLimiterMaxRequest"`
+	RateLimiterTimeSecond      int               `json:"rateLimiterTimeSecond"`
+	InternalService            InternalService   `json:"internalService"`
+	GCSType                    string            `json:"gcsType"`
+	GCSProjectID               string            `json:"gcsProjectID"`
+	GCSPrivateKeyID            string            `json:"gcsPrivateKeyID"`
+	GCSPrivateKey              string            `json:"gcsPrivateKey"`
+	GCSClientEmail             string            `json:"gcsClientEmail"`
+	GCSClientID                string            `json:"gcsClientID"`
+	GCSAuthURI                 string            `json:"gcsAuthURI"`
+	GCSTokenURI                string            `json:"gcsTokenURI"`
+	GCSAuthProviderX509CertURL string            `json:"gcsAuthProviderX509CertURL"`
+	GCSClientX509CertURL       string            `json:"gcsClientX509CertURL"`
+	GCSUniverseDomain          string            `json:"gcsUniverseDomain"`
+	GCSBucketName              string            `json:"gcsBucketName"`
+	Kafka                      Kafka             `json:"kafka"`
+	Midtrans                   Midtrans          `json:"midtrans"`
+	PaymentGateway             string            `json:"paymentGateway"`
+	FakeGateway                FakeGateway       `json:"fakeGateway"`
+	Reconciliation             Reconciliation    `json:"reconciliation"`
+	Outbox                     Outbox            `json:"outbox"`
+	TrustedProxies             []string          `json:"trustedProxies"`
+	IdempotencyLockInSeconds   int               `json:"idempotencyLockInSeconds"`
+	InternalCallers            map[string]string `json:"internalCallers"`
 }
 
 type Database struct {
@@ -57,16 +64,32 @@ type User struct {
 }
 
 type Kafka struct {
-	Brokers     []string `json:"brokers"`
-	TimeoutInMS int      `json:"timeoutInMS"`
-	MaxRetry    int      `json:"maxRetry"`
-	Topic       string   `json:"topic"`
+	Brokers      []string `json:"brokers"`
+	TimeoutInMS  int      `json:"timeoutInMS"`
+	MaxRetry     int      `json:"maxRetry"`
+	Topic        string   `json:"topic"`
+	EventVersion int      `json:"eventVersion"`
 }
 
 type Midtrans struct {
-	ServerKey    string `json:"serverKey"`
-	ClientKey    string `json:"clientKey"`
-	IsProduction bool   `json:"isProduction"`
+	ServerKey    string   `json:"serverKey"`
+	ClientKey    string   `json:"clientKey"`
+	IsProduction bool     `json:"isProduction"`
+	AllowedIPs   []string `json:"allowedIPs"`
+}
+
+type FakeGateway struct {
+	BaseURL string `json:"baseURL"`
+}
+
+type Reconciliation struct {
+	IntervalInSeconds int    `json:"intervalInSeconds"`
+	ReportDir         string `json:"reportDir"`
+}
+
+type Outbox struct {
+	RelayIntervalInMS int `json:"relayIntervalInMS"`
+	BatchSize         int `json:"batchSize"`
 }
 
 func Init() {
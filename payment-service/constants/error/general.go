@@ -16,6 +16,9 @@ var (
 	ErrInvalidUploadFile   = errors.New("invalid upload file")
 	ErrSizeTooBig          = errors.New("size too big")
 	ErrForbidden           = errors.New("forbidden")
+
+	ErrIdempotencyKeyConflict   = errors.New("idempotency key has already been used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is still in progress")
 )
 
 var GeneralErrors = []error{
@@ -25,4 +28,6 @@ var GeneralErrors = []error{
 	ErrUnauthorized,
 	ErrInvalidToken,
 	ErrForbidden,
+	ErrIdempotencyKeyConflict,
+	ErrIdempotencyKeyInProgress,
 }
@@ -3,11 +3,21 @@ package error
 import "errors"
 
 var (
-	ErrPaymentNotFound = errors.New("payment not found")
-	ErrExpireAtInvalid = errors.New("expired time must be greater than current time")
+	ErrPaymentNotFound          = errors.New("payment not found")
+	ErrExpireAtInvalid          = errors.New("expired time must be greater than current time")
+	ErrPaymentCannotBeCancelled = errors.New("payment cannot be cancelled")
+	ErrInvalidWebhookSignature  = errors.New("invalid webhook signature")
+	ErrUnknownTransactionStatus = errors.New("unknown transaction status")
+	ErrPaymentCannotBeRefunded  = errors.New("payment cannot be refunded")
+	ErrRefundAmountInvalid      = errors.New("refund amount exceeds the refundable amount")
 )
 
 var PaymentErrors = []error{
 	ErrPaymentNotFound,
 	ErrExpireAtInvalid,
+	ErrPaymentCannotBeCancelled,
+	ErrInvalidWebhookSignature,
+	ErrUnknownTransactionStatus,
+	ErrPaymentCannotBeRefunded,
+	ErrRefundAmountInvalid,
 }
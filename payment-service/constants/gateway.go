@@ -0,0 +1,12 @@
+package constants
+
+const (
+	MidtransGateway = "midtrans"
+	FakeGateway     = "fake"
+)
+
+// Environments in which the fake gateway may be used
+const (
+	LocalEnv       = "local"
+	DevelopmentEnv = "development"
+)
@@ -3,8 +3,9 @@ package constants
 import "net/textproto"
 
 var (
-	XServiceName  = textproto.CanonicalMIMEHeaderKey("x-service-name")
-	XApiKey       = textproto.CanonicalMIMEHeaderKey("x-api-key")
-	XRequestAt    = textproto.CanonicalMIMEHeaderKey("x-request-at")
-	Authorization = textproto.CanonicalMIMEHeaderKey("x-authorization")
+	XServiceName   = textproto.CanonicalMIMEHeaderKey("x-service-name")
+	XApiKey        = textproto.CanonicalMIMEHeaderKey("x-api-key")
+	XRequestAt     = textproto.CanonicalMIMEHeaderKey("x-request-at")
+	Authorization  = textproto.CanonicalMIMEHeaderKey("x-authorization")
+	IdempotencyKey = textproto.CanonicalMIMEHeaderKey("idempotency-key")
 )
@@ -0,0 +1,8 @@
+package constants
+
+type IdempotencyStatus int
+
+const (
+	IdempotencyProcessing IdempotencyStatus = 100
+	IdempotencyCompleted  IdempotencyStatus = 200
+)
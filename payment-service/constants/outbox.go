@@ -0,0 +1,12 @@
+package constants
+
+type OutboxStatus int
+
+const (
+	OutboxPending   OutboxStatus = 100
+	OutboxProcessed OutboxStatus = 200
+)
+
+func (o OutboxStatus) Int() int {
+	return int(o)
+}
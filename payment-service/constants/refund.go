@@ -0,0 +1,32 @@
+package constants
+
+type RefundStatus int
+type RefundStatusString string
+
+const (
+	RefundRequested RefundStatus = 100
+	RefundSucceeded RefundStatus = 200
+	RefundFailed    RefundStatus = 300
+
+	RefundRequestedString RefundStatusString = "Requested"
+	RefundSucceededString RefundStatusString = "Succeeded"
+	RefundFailedString    RefundStatusString = "Failed"
+)
+
+var mapRefundStatusIntToString = map[RefundStatus]RefundStatusString{
+	RefundRequested: RefundRequestedString,
+	RefundSucceeded: RefundSucceededString,
+	RefundFailed:    RefundFailedString,
+}
+
+func (r RefundStatusString) String() string {
+	return string(r)
+}
+
+func (r RefundStatus) Int() int {
+	return int(r)
+}
+
+func (r RefundStatus) GetStatusString() RefundStatusString {
+	return mapRefundStatusIntToString[r]
+}
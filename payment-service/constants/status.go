@@ -1,32 +1,80 @@
 package constants
 
+import "strings"
+
 type PaymentStatus int
 type PaymentStatusString string
 
 const (
-	Initial    PaymentStatus = 0
-	Pending    PaymentStatus = 100
-	Settlement PaymentStatus = 200
-	Expire     PaymentStatus = 300
-
-	InitialString    PaymentStatusString = "Initial"
-	PendingString    PaymentStatusString = "Pending"
-	SettlementString PaymentStatusString = "Settlement"
-	ExpireString     PaymentStatusString = "Expire"
+	Initial       PaymentStatus = 0
+	Pending       PaymentStatus = 100
+	Settlement    PaymentStatus = 200
+	Expire        PaymentStatus = 300
+	Cancel        PaymentStatus = 400
+	Capture       PaymentStatus = 500
+	Challenge     PaymentStatus = 600
+	Deny          PaymentStatus = 700
+	Failure       PaymentStatus = 800
+	Refund        PaymentStatus = 900
+	PartialRefund PaymentStatus = 1000
+
+	InitialString       PaymentStatusString = "Initial"
+	PendingString       PaymentStatusString = "Pending"
+	SettlementString    PaymentStatusString = "Settlement"
+	ExpireString        PaymentStatusString = "Expire"
+	CancelString        PaymentStatusString = "Cancel"
+	CaptureString       PaymentStatusString = "Capture"
+	ChallengeString     PaymentStatusString = "Challenge"
+	DenyString          PaymentStatusString = "Deny"
+	FailureString       PaymentStatusString = "Failure"
+	RefundString        PaymentStatusString = "Refund"
+	PartialRefundString PaymentStatusString = "PartialRefund"
 )
 
+// Fraud status of a capture that needs to be reviewed on the midtrans dashboard
+const FraudStatusChallenge = "challenge"
+
 var mapStatusStringToInt = map[PaymentStatusString]PaymentStatus{
-	InitialString:    Initial,
-	PendingString:    Pending,
-	SettlementString: Settlement,
-	ExpireString:     Expire,
+	InitialString:       Initial,
+	PendingString:       Pending,
+	SettlementString:    Settlement,
+	ExpireString:        Expire,
+	CancelString:        Cancel,
+	CaptureString:       Capture,
+	ChallengeString:     Challenge,
+	DenyString:          Deny,
+	FailureString:       Failure,
+	RefundString:        Refund,
+	PartialRefundString: PartialRefund,
 }
 
 var mapStatusIntToString = map[PaymentStatus]PaymentStatusString{
-	Initial:    InitialString,
-	Pending:    PendingString,
-	Settlement: SettlementString,
-	Expire:     ExpireString,
+	Initial:       InitialString,
+	Pending:       PendingString,
+	Settlement:    SettlementString,
+	Expire:        ExpireString,
+	Cancel:        CancelString,
+	Capture:       CaptureString,
+	Challenge:     ChallengeString,
+	Deny:          DenyString,
+	Failure:       FailureString,
+	Refund:        RefundString,
+	PartialRefund: PartialRefundString,
+}
+
+// Status name used by midtrans and on the kafka event
+var mapStatusIntToEventStatus = map[PaymentStatus]string{
+	Initial:       "initial",
+	Pending:       "pending",
+	Settlement:    "settlement",
+	Expire:        "expire",
+	Cancel:        "cancel",
+	Capture:       "capture",
+	Challenge:     "challenge",
+	Deny:          "deny",
+	Failure:       "failure",
+	Refund:        "refund",
+	PartialRefund: "partial_refund",
 }
 
 func (p PaymentStatusString) String() string {
@@ -41,6 +89,17 @@ func (p PaymentStatus) GetStatusString() PaymentStatusString {
 	return mapStatusIntToString[p]
 }
 
+func (p PaymentStatus) GetEventStatus() string {
+	return mapStatusIntToEventStatus[p]
+}
+
+// Case insensitive, so both "Settlement" and midtrans "settlement" or "partial_refund" are matched
 func (p PaymentStatusString) GetStatusInt() PaymentStatus {
-	return mapStatusStringToInt[p]
+	normalized := strings.ReplaceAll(string(p), "_", "")
+	for statusString, status := range mapStatusStringToInt {
+		if strings.EqualFold(statusString.String(), normalized) {
+			return status
+		}
+	}
+	return Initial
 }
@@ -4,6 +4,9 @@ import (
 	"net/http"
 	errValidation "payment-service/common/error"
 	"payment-service/common/response"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	errPayment "payment-service/constants/error/payment"
 	"payment-service/domain/dto"
 	"payment-service/services"
 
@@ -18,7 +21,11 @@ type PaymentController struct {
 type IPaymentController interface {
 	GetAllWithPagination(*gin.Context)
 	GetByUUID(*gin.Context)
+	GetByUUIDs(*gin.Context)
+	GetHistory(*gin.Context)
 	Create(*gin.Context)
+	Cancel(*gin.Context)
+	Refund(*gin.Context)
 	Webhook(*gin.Context)
 }
 
@@ -91,6 +98,73 @@ func (p *PaymentController) GetByUUID(c *gin.Context) {
 	})
 }
 
+func (p *PaymentController) GetByUUIDs(c *gin.Context) {
+	// gin cannot escape the colon of the custom method, so it is matched as a parameter
+	if c.Param("action") != ":batch" {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	var request dto.BatchPaymentRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err = validate.Struct(request); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPRes{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := p.service.GetPayment().GetByUUIDs(c, &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (p *PaymentController) GetHistory(c *gin.Context) {
+	result, err := p.service.GetPayment().GetHistory(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
 func (p *PaymentController) Create(c *gin.Context) {
 	var request dto.PaymentRequest
 
@@ -118,10 +192,15 @@ func (p *PaymentController) Create(c *gin.Context) {
 		return
 	}
 
+	request.IdempotencyKey = c.GetHeader(constants.IdempotencyKey)
 	result, err := p.service.GetPayment().Create(c, &request)
 	if err != nil {
+		code := http.StatusBadRequest
+		if err == errConstant.ErrIdempotencyKeyConflict || err == errConstant.ErrIdempotencyKeyInProgress {
+			code = http.StatusConflict
+		}
 		response.HttpResponse(response.ParamHTTPRes{
-			Code: http.StatusBadRequest,
+			Code: code,
 			Err:  err,
 			Gin:  c,
 		})
@@ -135,6 +214,73 @@ func (p *PaymentController) Create(c *gin.Context) {
 	})
 }
 
+func (p *PaymentController) Cancel(c *gin.Context) {
+	uuid := c.Param("uuid")
+
+	result, err := p.service.GetPayment().Cancel(c, uuid)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (p *PaymentController) Refund(c *gin.Context) {
+	var (
+		request dto.RefundRequest
+		uuid    = c.Param("uuid")
+	)
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err = validate.Struct(request); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPRes{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := p.service.GetPayment().Refund(c.Request.Context(), uuid, &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
 func (p *PaymentController) Webhook(c *gin.Context) {
 	var request dto.Webhook
 
@@ -150,8 +296,12 @@ func (p *PaymentController) Webhook(c *gin.Context) {
 
 	err = p.service.GetPayment().Webhook(c, &request)
 	if err != nil {
+		code := http.StatusBadRequest
+		if err == errPayment.ErrInvalidWebhookSignature {
+			code = http.StatusUnauthorized
+		}
 		response.HttpResponse(response.ParamHTTPRes{
-			Code: http.StatusBadRequest,
+			Code: code,
 			Err:  err,
 			Gin:  c,
 		})
@@ -2,17 +2,21 @@ package kafka
 
 import (
 	configApp "payment-service/config"
+	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/sirupsen/logrus"
 )
 
 type Kafka struct {
-	brokers []string
+	brokers  []string
+	mutex    sync.Mutex
+	producer sarama.SyncProducer
 }
 
 type IKafka interface {
-	ProduceMessage(string, []byte) error
+	ProduceMessage(string, string, []byte) error
 }
 
 func NewKafkaProducer(brokers []string) IKafka {
@@ -21,28 +25,44 @@ func NewKafkaProducer(brokers []string) IKafka {
 	}
 }
 
-func (k *Kafka) ProduceMessage(topic string, data []byte) error {
+// The producer is created on first use and then kept open,
+// so the service can start while the broker is down
+func (k *Kafka) getProducer() (sarama.SyncProducer, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.producer != nil {
+		return k.producer, nil
+	}
+
 	config := sarama.NewConfig()
 	config.Producer.Return.Successes = true
 	config.Producer.RequiredAcks = sarama.WaitForAll
 	config.Producer.Retry.Max = configApp.Config.Kafka.MaxRetry
+	// Keyed messages of the same order always land on the same partition
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	if configApp.Config.Kafka.TimeoutInMS > 0 {
+		config.Producer.Timeout = time.Duration(configApp.Config.Kafka.TimeoutInMS) * time.Millisecond
+	}
 	producer, err := sarama.NewSyncProducer(k.brokers, config)
 	if err != nil {
 		logrus.Errorf("Failed to create producer: %v", err)
-		return err
+		return nil, err
 	}
 
-	// Close the producer to avoid data breach
-	defer func(producer sarama.SyncProducer) {
-		err = producer.Close()
-		if err != nil {
-			logrus.Errorf("Failed to close producer: %v", err)
-			return
-		}
-	}(producer)
+	k.producer = producer
+	return k.producer, nil
+}
+
+func (k *Kafka) ProduceMessage(topic string, key string, data []byte) error {
+	producer, err := k.getProducer()
+	if err != nil {
+		return err
+	}
 
 	message := &sarama.ProducerMessage{
 		Topic:   topic,
+		Key:     sarama.StringEncoder(key),
 		Headers: nil,
 		Value:   sarama.ByteEncoder(data),
 	}
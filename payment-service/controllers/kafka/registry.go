@@ -1,19 +1,20 @@
 package kafka
 
 type Registry struct {
-	brokers []string
+	producer IKafka
 }
 
 type IKafkaRegistry interface {
 	GetKafkaProducer() IKafka
 }
 
+// One producer is shared by the whole service
 func NewKafkaRegistry(brokers []string) IKafkaRegistry {
 	return &Registry{
-		brokers: brokers,
+		producer: NewKafkaProducer(brokers),
 	}
 }
 
 func (r *Registry) GetKafkaProducer() IKafka {
-	return NewKafkaProducer(r.brokers)
+	return r.producer
 }
@@ -22,4 +22,5 @@ type InvoicePaymentDetail struct {
 type InvoiceItem struct {
 	Description string `json:"description"`
 	Price       string `json:"price"`
+	IsDiscount  bool   `json:"isDiscount"`
 }
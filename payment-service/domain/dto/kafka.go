@@ -5,16 +5,8 @@ import (
 	"time"
 )
 
-type KafkaEvent struct {
-	Name string `json:"name"`
-}
-
-type KafkaMetaData struct {
-	Sender    string `json:"sender"`
-	SendingAt string `json:"sendingAt"`
-}
-
-type KafkaData struct {
+// Payload of the payment.status.changed event, version 1
+type PaymentEventV1 struct {
 	OrderID   uuid.UUID  `json:"orderID"`
 	PaymentID uuid.UUID  `json:"paymentID"`
 	Status    string     `json:"status"`
@@ -22,13 +14,12 @@ type KafkaData struct {
 	PaidAt    *time.Time `json:"paidAt"`
 }
 
-type KafkaBody struct {
-	Type string     `json:"type"`
-	Data *KafkaData `json:"data"`
-}
-
-type KafkaMessage struct {
-	Event    KafkaEvent    `json:"event"`
-	Metadata KafkaMetaData `json:"metadata"`
-	Body     KafkaBody     `json:"body"`
+// Version 2 adds the amount of the payment
+type PaymentEventV2 struct {
+	OrderID   uuid.UUID  `json:"orderID"`
+	PaymentID uuid.UUID  `json:"paymentID"`
+	Status    string     `json:"status"`
+	Amount    float64    `json:"amount"`
+	ExpiredAt time.Time  `json:"expiredAt"`
+	PaidAt    *time.Time `json:"paidAt"`
 }
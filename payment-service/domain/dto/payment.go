@@ -15,6 +15,7 @@ type PaymentRequest struct {
 	Description    *string         `json:"description"`
 	CustomerDetail *CustomerDetail `json:"customerDetail"`
 	ItemDetails    []ItemDetail    `json:"itemDetails"`
+	IdempotencyKey string          `json:"-"`
 }
 
 type CustomerDetail struct {
@@ -30,6 +31,10 @@ type ItemDetail struct {
 	Quantity int     `json:"quantity"`
 }
 
+type BatchPaymentRequest struct {
+	UUIDs []string `json:"uuids" validate:"required,min=1,max=100,dive,uuid"`
+}
+
 type PaymentRequestParam struct {
 	Page       int     `form:"page" validate:"required"`
 	Limit      int     `form:"limit" validate:"required"`
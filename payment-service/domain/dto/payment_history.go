@@ -1,8 +1,16 @@
 package dto
 
-import "payment-service/constants"
+import (
+	"payment-service/constants"
+	"time"
+)
 
 type PaymentHistoryRequest struct {
 	PaymentID uint                          `json:"paymentID"`
 	Status    constants.PaymentStatusString `json:"status"`
 }
+
+type PaymentHistoryResponse struct {
+	Status    constants.PaymentStatusString `json:"status"`
+	CreatedAt *time.Time                    `json:"createdAt"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "time"
+
+type PaymentOutboxStats struct {
+	Pending         int64
+	OldestCreatedAt *time.Time
+}
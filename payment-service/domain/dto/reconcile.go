@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReconcileReport struct {
+	StartedAt     time.Time       `json:"startedAt"`
+	FinishedAt    time.Time       `json:"finishedAt"`
+	Checked       int             `json:"checked"`
+	Applied       int             `json:"applied"`
+	Failed        int             `json:"failed"`
+	Discrepancies []ReconcileItem `json:"discrepancies"`
+}
+
+type ReconcileItem struct {
+	PaymentID     uuid.UUID `json:"paymentID"`
+	OrderID       uuid.UUID `json:"orderID"`
+	LocalStatus   string    `json:"localStatus"`
+	GatewayStatus string    `json:"gatewayStatus,omitempty"`
+	Applied       bool      `json:"applied"`
+	Error         string    `json:"error,omitempty"`
+}
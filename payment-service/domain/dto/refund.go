@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"payment-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefundRequest struct {
+	Amount *float64 `json:"amount" validate:"omitempty,gt=0"`
+	Reason string   `json:"reason" validate:"required"`
+	// Set by order-service, so a refund it relays again is requested once
+	RefundKey string `json:"refundKey" validate:"omitempty,max=100"`
+}
+
+type RefundResponse struct {
+	UUID      uuid.UUID                    `json:"uuid"`
+	PaymentID uuid.UUID                    `json:"paymentID"`
+	OrderID   uuid.UUID                    `json:"orderID"`
+	RefundKey string                       `json:"refundKey"`
+	Amount    float64                      `json:"amount"`
+	Reason    string                       `json:"reason"`
+	Status    constants.RefundStatusString `json:"status"`
+	CreatedAt *time.Time                   `json:"createdAt"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"payment-service/constants"
+	"time"
+)
+
+type IdempotencyKey struct {
+	ID          uint                        `gorm:"primaryKey;autoIncrement"`
+	Key         string                      `gorm:"type:varchar(255);not null;uniqueIndex"`
+	RequestHash string                      `gorm:"type:varchar(64);not null"`
+	Response    *string                     `gorm:"type:jsonb"`
+	Status      constants.IdempotencyStatus `gorm:"type:int;not null"`
+	LockedUntil *time.Time                  `gorm:"type:timestamp"`
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
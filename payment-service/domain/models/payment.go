@@ -1,7 +1,9 @@
 package models
 
 import (
+	"encoding/json"
 	"payment-service/constants"
+	"payment-service/domain/dto"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,9 +22,20 @@ type Payment struct {
 	Acquirer         *string                  `gorm:"type:varchar(100);default:null"`
 	TransactionID    *string                  `gorm:"type:varchar(100);default:null"`
 	Description      *string                  `gorm:"type:text;default:null"`
+	ItemDetails      *string                  `gorm:"type:jsonb;default:null"`
 	PaidAt           *time.Time
 	ExpiredAt        *time.Time
 	CreatedAt        *time.Time
 	UpdatedAt        *time.Time
 	PaymentHistories []PaymentHistory `gorm:"foreignKey:payment_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Refunds          []Refund         `gorm:"foreignKey:payment_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// Items sent to the payment gateway, empty for payments created before they were stored
+func (p *Payment) ItemDetailList() []dto.ItemDetail {
+	var items []dto.ItemDetail
+	if p.ItemDetails != nil {
+		_ = json.Unmarshal([]byte(*p.ItemDetails), &items)
+	}
+	return items
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"payment-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentOutbox struct {
+	ID            uint                   `gorm:"primaryKey;autoIncrement"`
+	UUID          uuid.UUID              `gorm:"type:uuid;not null"`
+	PaymentID     uint                   `gorm:"type:bigint;not null"`
+	OrderID       uuid.UUID              `gorm:"type:uuid;not null;index"`
+	Topic         string                 `gorm:"type:varchar(255);not null"`
+	Payload       string                 `gorm:"type:jsonb;not null"`
+	Status        constants.OutboxStatus `gorm:"type:int;not null"`
+	Attempts      int                    `gorm:"type:int;not null;default:0"`
+	NextAttemptAt time.Time              `gorm:"type:timestamp;not null"`
+	LastError     *string                `gorm:"type:text"`
+	ProcessedAt   *time.Time             `gorm:"type:timestamp"`
+	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
+}
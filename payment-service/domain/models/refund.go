@@ -0,0 +1,21 @@
+package models
+
+import (
+	"payment-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Refund struct {
+	ID          uint                   `gorm:"primaryKey;autoIncrement"`
+	UUID        uuid.UUID              `gorm:"type:uuid;not null"`
+	PaymentID   uint                   `gorm:"type:bigint;not null"`
+	RefundKey   string                 `gorm:"type:varchar(100);not null;uniqueIndex"`
+	Amount      float64                `gorm:"type:decimal(15,2);not null"`
+	Reason      string                 `gorm:"type:text;not null"`
+	Status      constants.RefundStatus `gorm:"type:int;not null"`
+	RequestedBy uuid.UUID              `gorm:"type:uuid;not null"`
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
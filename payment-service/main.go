@@ -1 +1,7 @@
 package main
+
+import "payment-service/cmd"
+
+func main() {
+	cmd.Run()
+}
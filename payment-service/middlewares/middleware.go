@@ -1,11 +1,13 @@
 package middlewares
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"net/http"
 	clients "payment-service/clients"
+	"payment-service/common/metrics"
 	"payment-service/common/response"
 	"payment-service/config"
 	"payment-service/constants"
@@ -51,7 +53,7 @@ func RateLimiter(lmt *limiter.Limiter) gin.HandlerFunc {
 }
 
 func extractBearerToken(token string) string {
-	arrayToken := strings.Split(token, "")
+	arrayToken := strings.Split(token, " ")
 	if len(arrayToken) == 2 {
 		return arrayToken[1]
 	}
@@ -66,11 +68,10 @@ func responseUnauthorized(c *gin.Context, message string) {
 	c.Abort()
 }
 
-func validateAPIKey(c *gin.Context) error {
+func validateAPIKey(c *gin.Context, signatureKey string) error {
 	apiKey := c.GetHeader(constants.XApiKey)
 	requestAt := c.GetHeader(constants.XRequestAt)
 	serviceName := c.GetHeader(constants.XServiceName)
-	signatureKey := config.Config.SignatureKey
 
 	validateKey := fmt.Sprintf("%s:%s:%s", serviceName, signatureKey, requestAt)
 	hash := sha256.New()
@@ -106,6 +107,8 @@ func CheckRole(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
 			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
 			return
 		}
+		userLogin := c.Request.WithContext(context.WithValue(c.Request.Context(), constants.User, user))
+		c.Request = userLogin
 		c.Next()
 	}
 }
@@ -115,16 +118,74 @@ func Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var err error
 		token := c.GetHeader(constants.Authorization)
-		if token != "" {
+		if token == "" {
 			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
 			return
 		}
 
-		err = validateAPIKey(c)
+		err = validateAPIKey(c, config.Config.SignatureKey)
+		if err != nil {
+			responseUnauthorized(c, err.Error())
+			return
+		}
+
+		tokenString := extractBearerToken(token)
+		tokenUser := c.Request.WithContext(context.WithValue(c.Request.Context(), constants.Token, tokenString))
+		c.Request = tokenUser
+		c.Next()
+	}
+}
+
+// Authenticate internal service call without user token
+func AuthenticateWithoutToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := validateAPIKey(c, config.Config.SignatureKey)
 		if err != nil {
 			responseUnauthorized(c, err.Error())
 			return
 		}
 	}
+}
 
+// Internal services on the allowlist only, each one signs with its own key
+func AuthenticateInternal() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		signatureKey, ok := config.Config.InternalCallers[c.GetHeader(constants.XServiceName)]
+		if !ok || signatureKey == "" {
+			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		err := validateAPIKey(c, signatureKey)
+		if err != nil {
+			responseUnauthorized(c, err.Error())
+			return
+		}
+		c.Next()
+	}
+}
+
+// Only accept the webhook from the allowed source IPs (allow all when empty).
+// The client IP comes from X-Forwarded-For only when the request passed a trusted proxy.
+func AllowWebhookIP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowedIPs := config.Config.Midtrans.AllowedIPs
+		if len(allowedIPs) == 0 {
+			c.Next()
+			return
+		}
+
+		clientIP := c.ClientIP()
+		if !containts(allowedIPs, clientIP) {
+			logrus.Warnf("webhook rejected, ip %s is not allowed", clientIP)
+			metrics.WebhookRejected.Add(metrics.WebhookRejectedIP, 1)
+			c.JSON(http.StatusForbidden, response.Response{
+				Status:  constants.Error,
+				Message: errConstant.ErrForbidden.Error(),
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
 }
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	errWrap "payment-service/common/error"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PaymentRepository struct {
@@ -22,7 +24,11 @@ type PaymentRepository struct {
 type IPaymentRepository interface {
 	FindAllWithPagination(context.Context, *dto.PaymentRequestParam) ([]models.Payment, int64, error)
 	FindByUUID(context.Context, string) (*models.Payment, error)
+	FindByUUIDs(context.Context, []string) ([]models.Payment, error)
 	FindByOrderID(context.Context, string) (*models.Payment, error)
+	LockByUUID(context.Context, *gorm.DB, string) (*models.Payment, error)
+	LockByOrderID(context.Context, *gorm.DB, string) (*models.Payment, error)
+	FindAllPending(context.Context) ([]models.Payment, error)
 	Create(context.Context, *gorm.DB, *dto.PaymentRequest) (*models.Payment, error)
 	Update(context.Context, *gorm.DB, string, *dto.UpdatePaymentRequest) (*models.Payment, error)
 }
@@ -87,6 +93,16 @@ func (p *PaymentRepository) FindByUUID(ctx context.Context, uuid string) (*model
 	return &payment, nil
 }
 
+// Find by UUIDs
+func (p *PaymentRepository) FindByUUIDs(ctx context.Context, uuids []string) ([]models.Payment, error) {
+	var payments []models.Payment
+	err := p.db.WithContext(ctx).Where("uuid IN ?", uuids).Find(&payments).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return payments, nil
+}
+
 // Find by Order ID
 func (p *PaymentRepository) FindByOrderID(ctx context.Context, orderID string) (*models.Payment, error) {
 	var payment models.Payment
@@ -102,10 +118,61 @@ func (p *PaymentRepository) FindByOrderID(ctx context.Context, orderID string) (
 	return &payment, nil
 }
 
+// Find by UUID and lock the payment until the transaction ends
+func (p *PaymentRepository) LockByUUID(ctx context.Context, tx *gorm.DB, uuid string) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", uuid).First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPayment.ErrPaymentNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &payment, nil
+}
+
+// Find by Order ID and lock the payment until the transaction ends
+func (p *PaymentRepository) LockByOrderID(ctx context.Context, tx *gorm.DB, orderID string) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", orderID).First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPayment.ErrPaymentNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &payment, nil
+}
+
+// Find all payments still waiting for the gateway
+func (p *PaymentRepository) FindAllPending(ctx context.Context) ([]models.Payment, error) {
+	var payments []models.Payment
+
+	err := p.db.WithContext(ctx).
+		Where("status IN ?", []constants.PaymentStatus{constants.Initial, constants.Pending}).
+		Order("created_at asc").
+		Find(&payments).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return payments, nil
+}
+
 // Create Payment
 func (p *PaymentRepository) Create(ctx context.Context, tx *gorm.DB, req *dto.PaymentRequest) (*models.Payment, error) {
 	status := constants.Initial
 	orderID := uuid.MustParse(req.OrderID)
+
+	var itemDetails *string
+	if len(req.ItemDetails) > 0 {
+		items, err := json.Marshal(req.ItemDetails)
+		if err != nil {
+			return nil, errWrap.WrapError(err)
+		}
+		itemDetailsString := string(items)
+		itemDetails = &itemDetailsString
+	}
+
 	payment := models.Payment{
 		UUID:        uuid.New(),
 		OrderID:     orderID,
@@ -113,6 +180,7 @@ func (p *PaymentRepository) Create(ctx context.Context, tx *gorm.DB, req *dto.Pa
 		PaymentLink: req.PaymentLink,
 		ExpiredAt:   &req.ExpiredAt,
 		Description: req.Description,
+		ItemDetails: itemDetails,
 		Status:      &status,
 	}
 
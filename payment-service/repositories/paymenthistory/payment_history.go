@@ -15,6 +15,7 @@ type PaymentHistoryRepository struct {
 }
 
 type IPaymentHistoryRepository interface {
+	FindByPaymentID(context.Context, uint) ([]models.PaymentHistory, error)
 	Create(context.Context, *gorm.DB, *dto.PaymentHistoryRequest) error
 }
 
@@ -22,6 +23,21 @@ func NewPaymentHistoryRepository(db *gorm.DB) IPaymentHistoryRepository {
 	return &PaymentHistoryRepository{db: db}
 }
 
+// Find by Payment ID, the oldest first
+func (p *PaymentHistoryRepository) FindByPaymentID(ctx context.Context, paymentID uint) ([]models.PaymentHistory, error) {
+	var histories []models.PaymentHistory
+	err := p.db.
+		WithContext(ctx).
+		Where("payment_id = ?", paymentID).
+		Order("created_at asc, id asc").
+		Find(&histories).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return histories, nil
+}
+
 func (p *PaymentHistoryRepository) Create(
 	ctx context.Context,
 	tx *gorm.DB,
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PaymentOutboxRepository struct {
+	db *gorm.DB
+}
+
+type IPaymentOutboxRepository interface {
+	FindAllDue(context.Context, time.Time, int) ([]models.PaymentOutbox, error)
+	GetStats(context.Context) (*dto.PaymentOutboxStats, error)
+	Create(context.Context, *gorm.DB, *models.PaymentOutbox) (*models.PaymentOutbox, error)
+	Update(context.Context, *models.PaymentOutbox) error
+}
+
+func NewPaymentOutboxRepository(db *gorm.DB) IPaymentOutboxRepository {
+	return &PaymentOutboxRepository{db: db}
+}
+
+// Find the pending entries that are ready to be relayed, only the oldest one of each order
+// so the events of an order are never published out of order
+func (p *PaymentOutboxRepository) FindAllDue(ctx context.Context, now time.Time, limit int) ([]models.PaymentOutbox, error) {
+	var outboxes []models.PaymentOutbox
+	err := p.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", constants.OutboxPending, now).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM payment_outboxes previous
+			WHERE previous.order_id = payment_outboxes.order_id
+			AND previous.status = ?
+			AND previous.id < payment_outboxes.id
+		)`, constants.OutboxPending).
+		Order("id asc").
+		Limit(limit).
+		Find(&outboxes).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return outboxes, nil
+}
+
+// Number of pending entries and the creation time of the oldest one
+func (p *PaymentOutboxRepository) GetStats(ctx context.Context) (*dto.PaymentOutboxStats, error) {
+	var stats dto.PaymentOutboxStats
+	err := p.db.WithContext(ctx).
+		Model(&models.PaymentOutbox{}).
+		Select("COUNT(*) AS pending, MIN(created_at) AS oldest_created_at").
+		Where("status = ?", constants.OutboxPending).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &stats, nil
+}
+
+func (p *PaymentOutboxRepository) Create(
+	ctx context.Context,
+	tx *gorm.DB,
+	param *models.PaymentOutbox,
+) (*models.PaymentOutbox, error) {
+	outbox := models.PaymentOutbox{
+		UUID:          uuid.New(),
+		PaymentID:     param.PaymentID,
+		OrderID:       param.OrderID,
+		Topic:         param.Topic,
+		Payload:       param.Payload,
+		Status:        constants.OutboxPending,
+		NextAttemptAt: time.Now(),
+	}
+
+	err := tx.WithContext(ctx).Create(&outbox).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &outbox, nil
+}
+
+func (p *PaymentOutboxRepository) Update(ctx context.Context, param *models.PaymentOutbox) error {
+	err := p.db.WithContext(ctx).
+		Model(&models.PaymentOutbox{}).
+		Where("id = ?", param.ID).
+		Select("status", "attempts", "next_attempt_at", "last_error", "processed_at").
+		Updates(param).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	"payment-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefundRepository struct {
+	db *gorm.DB
+}
+
+type IRefundRepository interface {
+	FindByRefundKey(context.Context, *gorm.DB, string) (*models.Refund, error)
+	SumActiveByPaymentID(context.Context, *gorm.DB, uint) (float64, error)
+	Create(context.Context, *gorm.DB, *models.Refund) (*models.Refund, error)
+	UpdateStatus(context.Context, *gorm.DB, uint, constants.RefundStatus) error
+}
+
+func NewRefundRepository(db *gorm.DB) IRefundRepository {
+	return &RefundRepository{db: db}
+}
+
+// Find by refund key, returns nil when no refund has the key
+func (r *RefundRepository) FindByRefundKey(ctx context.Context, tx *gorm.DB, refundKey string) (*models.Refund, error) {
+	var refund models.Refund
+	err := tx.WithContext(ctx).Where("refund_key = ?", refundKey).First(&refund).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &refund, nil
+}
+
+// Total amount refunded or still being refunded for the payment
+func (r *RefundRepository) SumActiveByPaymentID(ctx context.Context, tx *gorm.DB, paymentID uint) (float64, error) {
+	var total float64
+	err := tx.WithContext(ctx).
+		Model(&models.Refund{}).
+		Where("payment_id = ? AND status IN ?", paymentID,
+			[]constants.RefundStatus{constants.RefundRequested, constants.RefundSucceeded}).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return total, nil
+}
+
+func (r *RefundRepository) Create(ctx context.Context, tx *gorm.DB, req *models.Refund) (*models.Refund, error) {
+	refund := models.Refund{
+		UUID:        uuid.New(),
+		PaymentID:   req.PaymentID,
+		RefundKey:   req.RefundKey,
+		Amount:      req.Amount,
+		Reason:      req.Reason,
+		Status:      constants.RefundRequested,
+		RequestedBy: req.RequestedBy,
+	}
+
+	err := tx.WithContext(ctx).Create(&refund).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &refund, nil
+}
+
+func (r *RefundRepository) UpdateStatus(ctx context.Context, tx *gorm.DB, id uint, status constants.RefundStatus) error {
+	err := tx.WithContext(ctx).Model(&models.Refund{}).Where("id = ?", id).Update("status", status).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package repositories
 
 import (
+	repoIdempotencyKey "payment-service/repositories/idempotencykey"
 	repoPayment "payment-service/repositories/payment"
 	repositories "payment-service/repositories/payment"
 	repoHistory "payment-service/repositories/paymenthistory"
+	repoOutbox "payment-service/repositories/paymentoutbox"
+	repoRefund "payment-service/repositories/refund"
 
 	"gorm.io/gorm"
 )
@@ -15,6 +18,9 @@ type Registry struct {
 type IRepositoryRegistry interface {
 	GetPayment() repoPayment.IPaymentRepository
 	GetPaymentHistory() repoHistory.IPaymentHistoryRepository
+	GetIdempotencyKey() repoIdempotencyKey.IIdempotencyKeyRepository
+	GetRefund() repoRefund.IRefundRepository
+	GetPaymentOutbox() repoOutbox.IPaymentOutboxRepository
 	GetTx() *gorm.DB
 }
 
@@ -30,6 +36,18 @@ func (r *Registry) GetPaymentHistory() repoHistory.IPaymentHistoryRepository {
 	return repoHistory.NewPaymentHistoryRepository(r.db)
 }
 
+func (r *Registry) GetIdempotencyKey() repoIdempotencyKey.IIdempotencyKeyRepository {
+	return repoIdempotencyKey.NewIdempotencyKeyRepository(r.db)
+}
+
+func (r *Registry) GetRefund() repoRefund.IRefundRepository {
+	return repoRefund.NewRefundRepository(r.db)
+}
+
+func (r *Registry) GetPaymentOutbox() repoOutbox.IPaymentOutboxRepository {
+	return repoOutbox.NewPaymentOutboxRepository(r.db)
+}
+
 func (r *Registry) GetTx() *gorm.DB {
 	return r.db
 }
@@ -28,15 +28,30 @@ func NewPaymentRoute(group *gin.RouterGroup, controller controllers.IControllerR
 }
 
 func (p *PaymentRoute) Run() {
+	// Batch lookup (called by order-service for the orders it already scoped to the user),
+	// registered on the parent group as the custom method of the collection
+	p.group.POST("/payment:action", middlewares.AuthenticateInternal(), p.controller.GetPayment().GetByUUIDs)
+
 	group := p.group.Group("/payment")
-	group.POST("/webhook", p.controller.GetPayment().Webhook)
+	group.POST("/webhook", middlewares.AllowWebhookIP(), p.controller.GetPayment().Webhook)
+
+	// Internal routes (called by order-service)
+	// The payment link is created by the outbox relay of order-service, which has no customer token to pass on,
+	// so the route only accepts order-service itself
+	group.POST("", middlewares.AuthenticateInternal(), p.controller.GetPayment().Create)
+	group.POST("/:uuid/cancel", middlewares.AuthenticateInternal(), p.controller.GetPayment().Cancel)
+	group.POST("/:uuid/refund/internal", middlewares.AuthenticateInternal(), p.controller.GetPayment().Refund)
+	// The order timeline, order-service checks that the order belongs to the customer
+	group.GET("/:uuid/history/internal", middlewares.AuthenticateInternal(), p.controller.GetPayment().GetHistory)
 
 	// User midlleware from here
 	group.Use(middlewares.Authenticate())
 
 	group.GET("", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, p.client), p.controller.GetPayment().GetAllWithPagination)
 
-	group.GET(":id", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, p.client), p.controller.GetPayment().GetByUUID)
+	group.GET("/:uuid", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, p.client), p.controller.GetPayment().GetByUUID)
+
+	group.GET("/:uuid/history", middlewares.CheckRole([]string{constants.Admin}, p.client), p.controller.GetPayment().GetHistory)
 
-	group.POST("", middlewares.CheckRole([]string{constants.Customer}, p.client), p.controller.GetPayment().Create)
+	group.POST("/:uuid/refund", middlewares.CheckRole([]string{constants.Admin}, p.client), p.controller.GetPayment().Refund)
 }
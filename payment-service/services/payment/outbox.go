@@ -0,0 +1,136 @@
+package services
+
+import (
+	"common/event"
+	"context"
+	"payment-service/common/metrics"
+	paymentConfig "payment-service/config"
+	"payment-service/constants"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Store the payment event in the outbox within the transaction of the status update
+func (p *PaymentService) enqueueEvent(
+	ctx context.Context,
+	tx *gorm.DB,
+	status constants.PaymentStatus,
+	payment *models.Payment,
+	paidAt *time.Time,
+) error {
+	// Pinned to an older version while the consumers are not migrated yet
+	version := paymentConfig.Config.Kafka.EventVersion
+	if version <= 0 {
+		version = event.PaymentStatusChangedLatestVersion
+	}
+
+	var payload any
+	switch version {
+	case 1:
+		payload = dto.PaymentEventV1{
+			OrderID:   payment.OrderID,
+			PaymentID: payment.UUID,
+			Status:    status.GetEventStatus(),
+			ExpiredAt: *payment.ExpiredAt,
+			PaidAt:    paidAt,
+		}
+	default:
+		payload = dto.PaymentEventV2{
+			OrderID:   payment.OrderID,
+			PaymentID: payment.UUID,
+			Status:    status.GetEventStatus(),
+			Amount:    payment.Amount,
+			ExpiredAt: *payment.ExpiredAt,
+			PaidAt:    paidAt,
+		}
+	}
+
+	message, err := event.New(event.PaymentStatusChanged, version, "payment-service", payment.OrderID.String(), payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.repository.GetPaymentOutbox().Create(ctx, tx, &models.PaymentOutbox{
+		PaymentID: payment.ID,
+		OrderID:   payment.OrderID,
+		Topic:     paymentConfig.Config.Kafka.Topic,
+		Payload:   string(message),
+	})
+	return err
+}
+
+// Exponential backoff between the relay attempts, capped at 10 minutes
+func (p *PaymentService) outboxBackoff(attempts int) time.Duration {
+	backoff := time.Second
+	for i := 1; i < attempts && backoff < 10*time.Minute; i++ {
+		backoff *= 2
+	}
+	if backoff > 10*time.Minute {
+		backoff = 10 * time.Minute
+	}
+	return backoff
+}
+
+// Publish the outbox entry keyed by the order ID, a failed entry is retried until the broker is back
+func (p *PaymentService) publishOutbox(ctx context.Context, outbox *models.PaymentOutbox) error {
+	err := p.kafka.GetKafkaProducer().ProduceMessage(outbox.Topic, outbox.OrderID.String(), []byte(outbox.Payload))
+	if err != nil {
+		metrics.PaymentOutboxRelayed.Add(metrics.PaymentOutboxFailed, 1)
+		lastError := err.Error()
+		outbox.Attempts++
+		outbox.LastError = &lastError
+		outbox.NextAttemptAt = time.Now().Add(p.outboxBackoff(outbox.Attempts))
+		updateErr := p.repository.GetPaymentOutbox().Update(ctx, outbox)
+		if updateErr != nil {
+			logrus.Errorf("failed to record the relay attempt of outbox %s: %v", outbox.UUID, updateErr)
+		}
+		return err
+	}
+
+	metrics.PaymentOutboxRelayed.Add(metrics.PaymentOutboxPublished, 1)
+	now := time.Now()
+	outbox.Attempts++
+	outbox.Status = constants.OutboxProcessed
+	outbox.ProcessedAt = &now
+	return p.repository.GetPaymentOutbox().Update(ctx, outbox)
+}
+
+// Publish the due outbox entries, used by the outbox worker
+func (p *PaymentService) RelayOutbox(ctx context.Context) error {
+	batchSize := paymentConfig.Config.Outbox.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	outboxes, err := p.repository.GetPaymentOutbox().FindAllDue(ctx, time.Now(), batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, outbox := range outboxes {
+		err = p.publishOutbox(ctx, &outbox)
+		if err != nil {
+			logrus.Errorf("failed to relay outbox %s: %v", outbox.UUID, err)
+		}
+	}
+	return p.recordOutboxLag(ctx)
+}
+
+func (p *PaymentService) recordOutboxLag(ctx context.Context) error {
+	stats, err := p.repository.GetPaymentOutbox().GetStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	var lag float64
+	if stats.OldestCreatedAt != nil {
+		lag = time.Since(*stats.OldestCreatedAt).Seconds()
+	}
+	metrics.PaymentOutboxPending.Set(stats.Pending)
+	metrics.PaymentOutboxLagSeconds.Set(lag)
+	return nil
+}
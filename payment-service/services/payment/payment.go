@@ -2,16 +2,23 @@ package services
 
 import (
 	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
 	"os"
-	clients "payment-service/clients/midtrans"
+	"path/filepath"
+	clients "payment-service/clients/gateway"
+	clientUser "payment-service/clients/user"
 	"payment-service/common/gcs"
+	"payment-service/common/metrics"
 	"payment-service/common/utils"
 	paymentConfig "payment-service/config"
 	"payment-service/constants"
+	errConstant "payment-service/constants/error"
 	errPayment "payment-service/constants/error/payment"
 	"payment-service/controllers/kafka"
 	"payment-service/domain/dto"
@@ -20,6 +27,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
@@ -27,27 +35,33 @@ type PaymentService struct {
 	repository repositories.IRepositoryRegistry
 	gcs        gcs.IGCSClient
 	kafka      kafka.IKafkaRegistry
-	midtrans   clients.IMidtransClient
+	gateway    clients.IPaymentGateway
 }
 
 type IPaymentService interface {
 	GetAllWithPagination(context.Context, *dto.PaymentRequestParam) (*utils.PaginationResult, error)
 	GetByUUID(context.Context, string) (*dto.PaymentResponse, error)
+	GetByUUIDs(context.Context, *dto.BatchPaymentRequest) ([]dto.PaymentResponse, error)
+	GetHistory(context.Context, string) ([]dto.PaymentHistoryResponse, error)
 	Create(context.Context, *dto.PaymentRequest) (*dto.PaymentResponse, error)
+	Cancel(context.Context, string) (*dto.PaymentResponse, error)
+	Refund(context.Context, string, *dto.RefundRequest) (*dto.RefundResponse, error)
 	Webhook(context.Context, *dto.Webhook) error
+	Reconcile(context.Context) (*dto.ReconcileReport, error)
+	RelayOutbox(context.Context) error
 }
 
 func NewPaymentService(
 	repository repositories.IRepositoryRegistry,
 	gcs gcs.IGCSClient,
 	kafka kafka.IKafkaRegistry,
-	midtrans clients.IMidtransClient,
+	gateway clients.IPaymentGateway,
 ) IPaymentService {
 	return &PaymentService{
 		repository: repository,
 		gcs:        gcs,
 		kafka:      kafka,
-		midtrans:   midtrans,
+		gateway:    gateway,
 	}
 }
 
@@ -96,7 +110,26 @@ func (p *PaymentService) GetByUUID(ctx context.Context, uuid string) (*dto.Payme
 	if err != nil {
 		return nil, err
 	}
-	return &dto.PaymentResponse{
+	response := p.toPaymentResponse(payment)
+	return &response, nil
+}
+
+// Get by UUIDs, unknown UUIDs are left out
+func (p *PaymentService) GetByUUIDs(ctx context.Context, req *dto.BatchPaymentRequest) ([]dto.PaymentResponse, error) {
+	payments, err := p.repository.GetPayment().FindByUUIDs(ctx, req.UUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.PaymentResponse, 0, len(payments))
+	for _, payment := range payments {
+		response = append(response, p.toPaymentResponse(&payment))
+	}
+	return response, nil
+}
+
+func (p *PaymentService) toPaymentResponse(payment *models.Payment) dto.PaymentResponse {
+	return dto.PaymentResponse{
 		UUID:          payment.UUID,
 		TransactionID: payment.TransactionID,
 		OrderID:       payment.OrderID,
@@ -110,25 +143,144 @@ func (p *PaymentService) GetByUUID(ctx context.Context, uuid string) (*dto.Payme
 		ExpiredAt:     payment.ExpiredAt,
 		CreatedAt:     payment.CreatedAt,
 		UpdatedAt:     payment.UpdatedAt,
-	}, nil
+	}
+}
+
+// Get History
+func (p *PaymentService) GetHistory(ctx context.Context, uuid string) ([]dto.PaymentHistoryResponse, error) {
+	payment, err := p.repository.GetPayment().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := p.repository.GetPaymentHistory().FindByPaymentID(ctx, payment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.PaymentHistoryResponse, 0, len(histories))
+	for _, history := range histories {
+		response = append(response, dto.PaymentHistoryResponse{
+			Status:    history.Status,
+			CreatedAt: history.CreatedAt,
+		})
+	}
+	return response, nil
 }
 
 // Create
 func (p *PaymentService) Create(ctx context.Context, req *dto.PaymentRequest) (*dto.PaymentResponse, error) {
+	if req.IdempotencyKey == "" {
+		return p.create(ctx, req)
+	}
+
+	var response dto.PaymentResponse
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	lockSeconds := paymentConfig.Config.IdempotencyLockInSeconds
+	if lockSeconds <= 0 {
+		lockSeconds = 300
+	}
+	now := time.Now()
+	lockedUntil := now.Add(time.Duration(lockSeconds) * time.Second)
+	idempotencyKey := &models.IdempotencyKey{
+		Key:         req.IdempotencyKey,
+		RequestHash: utils.GenerateSha256(string(body)),
+		LockedUntil: &lockedUntil,
+	}
+	reserved, err := p.repository.GetIdempotencyKey().Reserve(ctx, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replay, return the original response
+	if !reserved {
+		existing, err := p.repository.GetIdempotencyKey().FindByKey(ctx, req.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, errConstant.ErrIdempotencyKeyInProgress
+		}
+		if existing.RequestHash != idempotencyKey.RequestHash {
+			return nil, errConstant.ErrIdempotencyKeyConflict
+		}
+
+		if existing.Status == constants.IdempotencyCompleted && existing.Response != nil {
+			err = json.Unmarshal([]byte(*existing.Response), &response)
+			if err != nil {
+				return nil, err
+			}
+			return &response, nil
+		}
+
+		// The request that held the key never finished, it is retried once the lock expires
+		reclaimed, err := p.repository.GetIdempotencyKey().Reclaim(ctx, existing.ID, now, lockedUntil)
+		if err != nil {
+			return nil, err
+		}
+		if !reclaimed {
+			return nil, errConstant.ErrIdempotencyKeyInProgress
+		}
+		idempotencyKey.ID = existing.ID
+	}
+
+	result, err := p.create(ctx, req)
+	if err != nil {
+		// Free the key so the caller can retry the failed request
+		deleteErr := p.repository.GetIdempotencyKey().Delete(ctx, idempotencyKey.ID)
+		if deleteErr != nil {
+			logrus.Errorf("failed to release idempotency key %s: %v", req.IdempotencyKey, deleteErr)
+		}
+		return nil, err
+	}
+
+	body, err = json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.repository.GetIdempotencyKey().Complete(ctx, idempotencyKey.ID, string(body))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *PaymentService) create(ctx context.Context, req *dto.PaymentRequest) (*dto.PaymentResponse, error) {
 	var (
 		txErr, err error
 		payment    *models.Payment
 		response   *dto.PaymentResponse
-		midtrans   *clients.MidtransData
+		link       *clients.PaymentLink
 	)
 
+	// The order-service relay may retry, return the payment already created for the order
+	payment, err = p.repository.GetPayment().FindByOrderID(ctx, req.OrderID)
+	if err != nil && !errors.Is(err, errPayment.ErrPaymentNotFound) {
+		return nil, err
+	}
+	if payment != nil {
+		return &dto.PaymentResponse{
+			UUID:        payment.UUID,
+			OrderID:     payment.OrderID,
+			Amount:      payment.Amount,
+			Status:      payment.Status.GetStatusString(),
+			PaymentLink: payment.PaymentLink,
+			Description: payment.Description,
+		}, nil
+	}
+
 	// Start Transaction Here
 	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
 		if !req.ExpiredAt.After(time.Now()) {
 			return errPayment.ErrExpireAtInvalid
 		}
 
-		midtrans, txErr = p.midtrans.CreatePaymentLink(req)
+		link, txErr = p.gateway.CreatePaymentLink(req)
 		if txErr != nil {
 			return txErr
 		}
@@ -138,7 +290,7 @@ func (p *PaymentService) Create(ctx context.Context, req *dto.PaymentRequest) (*
 			Amount:      req.Amount,
 			Description: req.Description,
 			ExpiredAt:   req.ExpiredAt,
-			PaymentLink: midtrans.RedirectURL,
+			PaymentLink: link.RedirectURL,
 		}
 		payment, txErr = p.repository.GetPayment().Create(ctx, tx, paymentRequest)
 		if txErr != nil {
@@ -166,6 +318,237 @@ func (p *PaymentService) Create(ctx context.Context, req *dto.PaymentRequest) (*
 	return response, nil
 }
 
+// Cancel
+func (p *PaymentService) Cancel(ctx context.Context, uuid string) (*dto.PaymentResponse, error) {
+	var (
+		err     error
+		status  constants.PaymentStatus
+		payment *models.Payment
+	)
+
+	payment, err = p.repository.GetPayment().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	orderID := payment.OrderID.String()
+	switch *payment.Status {
+	case constants.Initial, constants.Pending:
+		status = constants.Expire
+		err = p.gateway.ExpireTransaction(orderID)
+	case constants.Settlement, constants.Capture:
+		status = constants.Cancel
+		err = p.gateway.CancelTransaction(orderID)
+	case constants.Expire, constants.Cancel:
+		// Already closed, nothing to do
+		return p.GetByUUID(ctx, uuid)
+	default:
+		return nil, errPayment.ErrPaymentCannotBeCancelled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		_, txErr := p.repository.GetPayment().Update(ctx, tx, orderID, &dto.UpdatePaymentRequest{
+			Status: &status,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		return p.repository.GetPaymentHistory().Create(ctx, tx, &dto.PaymentHistoryRequest{
+			PaymentID: payment.ID,
+			Status:    status.GetStatusString(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetByUUID(ctx, uuid)
+}
+
+// Refund
+func (p *PaymentService) Refund(ctx context.Context, uuid string, req *dto.RefundRequest) (*dto.RefundResponse, error) {
+	var (
+		err     error
+		status  constants.PaymentStatus
+		payment *models.Payment
+		refund  *models.Refund
+		// The refund of the key is requested already
+		replayed bool
+		// Refunds requested by order-service have no user
+		user, _ = ctx.Value(constants.User).(*clientUser.UserData)
+	)
+
+	// The payment stays locked until the refund is recorded, so concurrent refunds see each other
+	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		payment, txErr = p.repository.GetPayment().LockByUUID(ctx, tx, uuid)
+		if txErr != nil {
+			return txErr
+		}
+
+		var existing *models.Refund
+		if req.RefundKey != "" {
+			existing, txErr = p.repository.GetRefund().FindByRefundKey(ctx, tx, req.RefundKey)
+			if txErr != nil {
+				return txErr
+			}
+			if existing != nil && existing.Status != constants.RefundFailed {
+				refund, replayed = existing, true
+				return nil
+			}
+		}
+
+		// Only a paid payment can be refunded
+		switch *payment.Status {
+		case constants.Settlement, constants.Capture, constants.PartialRefund:
+		default:
+			return errPayment.ErrPaymentCannotBeRefunded
+		}
+
+		refunded, txErr := p.repository.GetRefund().SumActiveByPaymentID(ctx, tx, payment.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		// Refund the remaining amount when no amount is given
+		refundable := payment.Amount - refunded
+		amount := refundable
+		if req.Amount != nil {
+			amount = *req.Amount
+		}
+		if amount <= 0 || amount > refundable {
+			return errPayment.ErrRefundAmountInvalid
+		}
+
+		status = constants.PartialRefund
+		if amount >= refundable {
+			status = constants.Refund
+		}
+
+		// The gateway call of the key failed before, it is tried again with the same key
+		if existing != nil {
+			refund = existing
+			return p.repository.GetRefund().UpdateStatus(ctx, tx, refund.ID, constants.RefundRequested)
+		}
+
+		refundKey := req.RefundKey
+		if refundKey == "" {
+			refundKey = fmt.Sprintf("%s-%d", payment.OrderID, time.Now().UnixNano())
+		}
+
+		// Record the request first, so a failed gateway call is still traceable
+		refundRequest := &models.Refund{
+			PaymentID: payment.ID,
+			RefundKey: refundKey,
+			Amount:    amount,
+			Reason:    req.Reason,
+		}
+		if user != nil {
+			refundRequest.RequestedBy = user.UUID
+		}
+		refund, txErr = p.repository.GetRefund().Create(ctx, tx, refundRequest)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	if replayed {
+		return &dto.RefundResponse{
+			UUID:      refund.UUID,
+			PaymentID: payment.UUID,
+			OrderID:   payment.OrderID,
+			RefundKey: refund.RefundKey,
+			Amount:    refund.Amount,
+			Reason:    refund.Reason,
+			Status:    refund.Status.GetStatusString(),
+			CreatedAt: refund.CreatedAt,
+		}, nil
+	}
+
+	err = p.gateway.RefundTransaction(payment.OrderID.String(), &clients.RefundRequest{
+		RefundKey: refund.RefundKey,
+		Amount:    refund.Amount,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		updateErr := p.repository.GetRefund().UpdateStatus(ctx, p.repository.GetTx(), refund.ID, constants.RefundFailed)
+		if updateErr != nil {
+			logrus.Errorf("failed to mark refund %s as failed: %v", refund.UUID, updateErr)
+		}
+		return nil, err
+	}
+
+	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		txErr := p.repository.GetRefund().UpdateStatus(ctx, tx, refund.ID, constants.RefundSucceeded)
+		if txErr != nil {
+			return txErr
+		}
+
+		_, txErr = p.repository.GetPayment().Update(ctx, tx, payment.OrderID.String(), &dto.UpdatePaymentRequest{
+			Status: &status,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = p.repository.GetPaymentHistory().Create(ctx, tx, &dto.PaymentHistoryRequest{
+			PaymentID: payment.ID,
+			Status:    status.GetStatusString(),
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		// Let order-service move the order to refunded and release the schedules
+		return p.enqueueEvent(ctx, tx, status, payment, payment.PaidAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RefundResponse{
+		UUID:      refund.UUID,
+		PaymentID: payment.UUID,
+		OrderID:   payment.OrderID,
+		RefundKey: refund.RefundKey,
+		Amount:    refund.Amount,
+		Reason:    refund.Reason,
+		Status:    constants.RefundSucceeded.GetStatusString(),
+		CreatedAt: refund.CreatedAt,
+	}, nil
+}
+
+// Invoice lines from the stored item details, payments without them show the description and total
+func (p *PaymentService) invoiceItems(payment *models.Payment, total string) []dto.InvoiceItem {
+	itemDetails := payment.ItemDetailList()
+	if len(itemDetails) == 0 {
+		var description string
+		if payment.Description != nil {
+			description = *payment.Description
+		}
+		return []dto.InvoiceItem{{Description: description, Price: total}}
+	}
+
+	items := make([]dto.InvoiceItem, 0, len(itemDetails))
+	for _, item := range itemDetails {
+		amount := item.Amount * float64(item.Quantity)
+		isDiscount := amount < 0
+		if isDiscount {
+			amount = -amount
+		}
+		items = append(items, dto.InvoiceItem{
+			Description: item.Name,
+			Price:       utils.RupiahFormat(&amount),
+			IsDiscount:  isDiscount,
+		})
+	}
+	return items
+}
+
 // Utils functions :
 func (p *PaymentService) convertToIndonesianMonth(englishMonth string) string {
 	monthMap := map[string]string{
@@ -228,60 +611,55 @@ func (p *PaymentService) randomNumber() int {
 	return number
 }
 
-func (p *PaymentService) mapTransactionStatusToEvent(status constants.PaymentStatusString) string {
-	var paymentStatus string
-	switch status {
-	case constants.PendingString:
-		paymentStatus = strings.ToUpper(constants.PendingString.String())
-	case constants.SettlementString:
-		paymentStatus = strings.ToUpper(constants.SettlementString.String())
-	case constants.ExpireString:
-		paymentStatus = strings.ToUpper(constants.ExpireString.String())
-	}
-	return paymentStatus
-}
-
-func (p *PaymentService) produceToKafka(
-	req *dto.Webhook,
-	payment *models.Payment,
-	paidAt *time.Time,
-) error {
-	event := dto.KafkaEvent{
-		Name: p.mapTransactionStatusToEvent(req.TransactionStatus),
+// Resolve the payment status of the notification, a capture under fraud review is a challenge
+func (p *PaymentService) resolveWebhookStatus(req *dto.Webhook) constants.PaymentStatus {
+	status := req.TransactionStatus.GetStatusInt()
+	if status == constants.Capture && strings.EqualFold(req.FraudStatus, constants.FraudStatusChallenge) {
+		return constants.Challenge
 	}
+	return status
+}
 
-	metadata := dto.KafkaMetaData{
-		Sender:    "payment-service",
-		SendingAt: time.Now().Format(time.RFC3339),
-	}
+// Settlement and accepted capture (card payment) mean the payment is paid
+func (p *PaymentService) isPaid(status constants.PaymentStatus) bool {
+	return status == constants.Settlement || status == constants.Capture
+}
 
-	body := dto.KafkaBody{
-		Type: "JSON",
-		Data: &dto.KafkaData{
-			OrderID:   payment.OrderID,
-			PaymentID: payment.UUID,
-			Status:    req.TransactionStatus.String(),
-			PaidAt:    paidAt,
-			ExpiredAt: *payment.ExpiredAt,
-		},
-	}
+// Midtrans signs the notification with SHA512(order_id + status_code + gross_amount + server key)
+func (p *PaymentService) verifyWebhookSignature(req *dto.Webhook) error {
+	payload := fmt.Sprintf("%s%s%s%s",
+		req.OrderID.String(),
+		req.StatusCode,
+		req.GrossAmount,
+		paymentConfig.Config.Midtrans.ServerKey,
+	)
+	hash := sha512.Sum512([]byte(payload))
+	signature := hex.EncodeToString(hash[:])
 
-	kafkaMessage := dto.KafkaMessage{
-		Event:    event,
-		Metadata: metadata,
-		Body:     body,
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(req.SignatureKey)) != 1 {
+		logrus.Warnf("webhook rejected, invalid signature for order %s", req.OrderID)
+		metrics.WebhookRejected.Add(metrics.WebhookRejectedSignature, 1)
+		return errPayment.ErrInvalidWebhookSignature
 	}
+	return nil
+}
 
-	topic := paymentConfig.Config.Kafka.Topic
-	kafkaMessageJSON, _ := json.Marshal(kafkaMessage)
-	err := p.kafka.GetKafkaProducer().ProduceMessage(topic, kafkaMessageJSON)
+func (p *PaymentService) Webhook(ctx context.Context, req *dto.Webhook) error {
+	err := p.verifyWebhookSignature(req)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	status := p.resolveWebhookStatus(req)
+	if status == constants.Initial {
+		logrus.Warnf("webhook rejected, unknown transaction status %s for order %s", req.TransactionStatus, req.OrderID)
+		return errPayment.ErrUnknownTransactionStatus
+	}
+	return p.processTransaction(ctx, req, status)
 }
 
-func (p *PaymentService) Webhook(ctx context.Context, req *dto.Webhook) error {
+// Apply the transaction status from the gateway, used by both the webhook and the reconciler
+func (p *PaymentService) processTransaction(ctx context.Context, req *dto.Webhook, status constants.PaymentStatus) error {
 	var (
 		txErr, err         error
 		paymentAfterUpdate *models.Payment
@@ -292,32 +670,49 @@ func (p *PaymentService) Webhook(ctx context.Context, req *dto.Webhook) error {
 
 	// Check the response from midtrans (settlement == success)
 	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
-		_, txErr = p.repository.GetPayment().FindByOrderID(ctx, req.OrderID.String())
+		var payment *models.Payment
+		payment, txErr = p.repository.GetPayment().LockByOrderID(ctx, tx, req.OrderID.String())
 		if txErr != nil {
 			return txErr
 		}
 
-		if req.TransactionStatus == constants.SettlementString {
-			now := time.Now()
-			paidAt = &now
+		// The refund requested through the service is applied already, its notification changes nothing
+		if (status == constants.Refund || status == constants.PartialRefund) && *payment.Status == status {
+			logrus.Infof("payment of order %s is already %s, ignoring notification", req.OrderID, status.GetStatusString())
+			return nil
 		}
 
-		status := req.TransactionStatus.GetStatusInt()
-		vaNumber := req.VANumbers[0].VaNumber
-		bank := req.VANumbers[0].Bank
+		// A settlement that follows the capture of the payment changes only its status,
+		// the paid time and the invoice are kept from the first one
+		var paid bool
+		if p.isPaid(status) {
+			paidAt = payment.PaidAt
+			if paidAt == nil {
+				now := time.Now()
+				paid, paidAt = true, &now
+			}
+		}
+
+		// Card payments (capture) have no virtual account
+		var vaNumber, bank *string
+		if len(req.VANumbers) > 0 {
+			vaNumber = &req.VANumbers[0].VaNumber
+			bank = &req.VANumbers[0].Bank
+		}
 		_, txErr = p.repository.GetPayment().Update(ctx, tx, req.OrderID.String(), &dto.UpdatePaymentRequest{
 			TransactionID: &req.TransactionID,
 			Status:        &status,
 			PaidAt:        paidAt,
-			VANumber:      &vaNumber,
-			Bank:          &bank,
+			VANumber:      vaNumber,
+			Bank:          bank,
 			Acquirer:      req.Acquirer,
 		})
 		if txErr != nil {
 			return txErr
 		}
 
-		paymentAfterUpdate, txErr = p.repository.GetPayment().FindByOrderID(ctx, req.OrderID.String())
+		// Read within the transaction, so the history and the invoice see the update
+		paymentAfterUpdate, txErr = p.repository.GetPayment().LockByOrderID(ctx, tx, req.OrderID.String())
 		if txErr != nil {
 			return txErr
 		}
@@ -327,8 +722,19 @@ func (p *PaymentService) Webhook(ctx context.Context, req *dto.Webhook) error {
 			PaymentID: paymentAfterUpdate.ID,
 			Status:    paymentAfterUpdate.Status.GetStatusString(),
 		})
+		if txErr != nil {
+			return txErr
+		}
+
+		if paid {
+			var bankName, vaNumber string
+			if paymentAfterUpdate.Bank != nil {
+				bankName = strings.ToUpper(*paymentAfterUpdate.Bank)
+			}
+			if paymentAfterUpdate.VANumber != nil {
+				vaNumber = *paymentAfterUpdate.VANumber
+			}
 
-		if req.TransactionStatus == constants.SettlementString {
 			paidDay := paidAt.Format("02")
 			paidMonth := p.convertToIndonesianMonth(paidAt.Format("January"))
 			paidYear := paidAt.Format("2006")
@@ -339,17 +745,12 @@ func (p *PaymentService) Webhook(ctx context.Context, req *dto.Webhook) error {
 				Data: dto.InvoiceData{
 					PaymentDetail: dto.InvoicePaymentDetail{
 						PaymentMethod: req.PaymentType,
-						BankName:      strings.ToUpper(*paymentAfterUpdate.Bank),
-						VANumber:      *paymentAfterUpdate.VANumber,
+						BankName:      bankName,
+						VANumber:      vaNumber,
 						Date:          fmt.Sprintf("%s %s %s", paidDay, paidMonth, paidYear),
 						IsPaid:        true,
 					},
-					Items: []dto.InvoiceItem{
-						{
-							Description: *paymentAfterUpdate.Description,
-							Price:       total,
-						},
-					},
+					Items: p.invoiceItems(paymentAfterUpdate, total),
 					Total: total,
 				},
 			}
@@ -371,16 +772,102 @@ func (p *PaymentService) Webhook(ctx context.Context, req *dto.Webhook) error {
 				return txErr
 			}
 		}
-		return nil
+
+		// Published by the outbox relay, so the event is never lost once the status is committed
+		return p.enqueueEvent(ctx, tx, status, paymentAfterUpdate, paidAt)
 	})
 	if err != nil {
 		return err
 	}
 
-	err = p.produceToKafka(req, paymentAfterUpdate, paidAt)
+	return nil
+}
+
+// Reconcile the pending payments with the gateway status, for webhooks that never arrived
+func (p *PaymentService) Reconcile(ctx context.Context) (*dto.ReconcileReport, error) {
+	payments, err := p.repository.GetPayment().FindAllPending(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	report := &dto.ReconcileReport{
+		StartedAt:     time.Now(),
+		Discrepancies: make([]dto.ReconcileItem, 0),
+	}
+	for _, payment := range payments {
+		report.Checked++
+		item := dto.ReconcileItem{
+			PaymentID:   payment.UUID,
+			OrderID:     payment.OrderID,
+			LocalStatus: payment.Status.GetStatusString().String(),
+		}
+
+		transaction, err := p.gateway.GetStatus(payment.OrderID.String())
+		if err != nil {
+			// The customer may not have chosen a payment method yet, only report it once expired
+			if payment.ExpiredAt == nil || time.Now().Before(*payment.ExpiredAt) {
+				continue
+			}
+			report.Failed++
+			item.Error = err.Error()
+			report.Discrepancies = append(report.Discrepancies, item)
+			continue
+		}
+
+		status := p.resolveWebhookStatus(transaction)
+		if status == constants.Initial || status == *payment.Status {
+			continue
+		}
+
+		item.GatewayStatus = status.GetStatusString().String()
+		err = p.processTransaction(ctx, transaction, status)
+		if err != nil {
+			report.Failed++
+			item.Error = err.Error()
+		} else {
+			report.Applied++
+			item.Applied = true
+		}
+		report.Discrepancies = append(report.Discrepancies, item)
+	}
+	report.FinishedAt = time.Now()
+
+	logrus.Infof("reconciliation checked %d payments, applied %d, failed %d",
+		report.Checked, report.Applied, report.Failed)
+
+	// A run that found everything in sync leaves no report behind
+	if len(report.Discrepancies) == 0 {
+		return report, nil
+	}
+
+	path, err := p.writeReconcileReport(report)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Warnf("reconciliation found %d discrepancies, report %s", len(report.Discrepancies), path)
+	return report, nil
+}
+
+func (p *PaymentService) writeReconcileReport(report *dto.ReconcileReport) (string, error) {
+	reportDir := paymentConfig.Config.Reconciliation.ReportDir
+	if reportDir == "" {
+		reportDir = "reports"
+	}
+
+	err := os.MkdirAll(reportDir, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(reportDir, fmt.Sprintf("reconcile-%s.json", report.StartedAt.Format("20060102-150405")))
+	err = os.WriteFile(path, body, 0o644)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
 }
@@ -1,7 +1,7 @@
 package services
 
 import (
-	clients "payment-service/clients/midtrans"
+	clients "payment-service/clients/gateway"
 	"payment-service/common/gcs"
 	"payment-service/controllers/kafka"
 	"payment-service/repositories"
@@ -12,22 +12,22 @@ type Registry struct {
 	repository repositories.IRepositoryRegistry
 	gcs        gcs.IGCSClient
 	kafka      kafka.IKafkaRegistry
-	midtrans   clients.IMidtransClient
+	gateway    clients.IPaymentGateway
 }
 
 type IServiceRegistry interface {
 	GetPayment() services.IPaymentService
 }
 
-func NewServiceRegistry(repository repositories.IRepositoryRegistry, gcs gcs.IGCSClient, kafka kafka.IKafkaRegistry, midtrans clients.IMidtransClient) IServiceRegistry {
+func NewServiceRegistry(repository repositories.IRepositoryRegistry, gcs gcs.IGCSClient, kafka kafka.IKafkaRegistry, gateway clients.IPaymentGateway) IServiceRegistry {
 	return &Registry{
 		repository: repository,
 		gcs:        gcs,
 		kafka:      kafka,
-		midtrans:   midtrans,
+		gateway:    gateway,
 	}
 }
 
 func (r *Registry) GetPayment() services.IPaymentService {
-	return services.NewPaymentService(r.repository, r.gcs, r.kafka, r.midtrans)
+	return services.NewPaymentService(r.repository, r.gcs, r.kafka, r.gateway)
 }
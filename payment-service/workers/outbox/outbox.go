@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"context"
+	"payment-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type OutboxWorker struct {
+	service  services.IServiceRegistry
+	interval time.Duration
+}
+
+type IOutboxWorker interface {
+	Start(context.Context)
+}
+
+func NewOutboxWorker(service services.IServiceRegistry, interval time.Duration) IOutboxWorker {
+	return &OutboxWorker{service: service, interval: interval}
+}
+
+// Publish the payment events stored in the outbox
+func (o *OutboxWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	logrus.Infof("outbox worker started, interval %s", o.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Infof("outbox worker stopped")
+			return
+		case <-ticker.C:
+			err := o.service.GetPayment().RelayOutbox(ctx)
+			if err != nil {
+				logrus.Errorf("failed to relay outbox: %v", err)
+			}
+		}
+	}
+}
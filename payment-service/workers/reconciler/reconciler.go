@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"context"
+	"payment-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ReconcilerWorker struct {
+	service  services.IServiceRegistry
+	interval time.Duration
+}
+
+type IReconcilerWorker interface {
+	Start(context.Context)
+}
+
+func NewReconcilerWorker(service services.IServiceRegistry, interval time.Duration) IReconcilerWorker {
+	return &ReconcilerWorker{service: service, interval: interval}
+}
+
+// Reconcile the pending payments with the gateway periodically
+func (r *ReconcilerWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	logrus.Infof("reconciler worker started, interval %s", r.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Infof("reconciler worker stopped")
+			return
+		case <-ticker.C:
+			_, err := r.service.GetPayment().Reconcile(ctx)
+			if err != nil {
+				logrus.Errorf("failed to reconcile payments: %v", err)
+			}
+		}
+	}
+}
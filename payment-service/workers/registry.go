@@ -0,0 +1,38 @@
+package workers
+
+import (
+	"payment-service/config"
+	"payment-service/services"
+	outboxWorker "payment-service/workers/outbox"
+	reconcilerWorker "payment-service/workers/reconciler"
+	"time"
+)
+
+type Registry struct {
+	service services.IServiceRegistry
+}
+
+type IWorkerRegistry interface {
+	GetReconciler() reconcilerWorker.IReconcilerWorker
+	GetOutbox() outboxWorker.IOutboxWorker
+}
+
+func NewWorkerRegistry(service services.IServiceRegistry) IWorkerRegistry {
+	return &Registry{service: service}
+}
+
+func (r *Registry) GetReconciler() reconcilerWorker.IReconcilerWorker {
+	interval := time.Duration(config.Config.Reconciliation.IntervalInSeconds) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	return reconcilerWorker.NewReconcilerWorker(r.service, interval)
+}
+
+func (r *Registry) GetOutbox() outboxWorker.IOutboxWorker {
+	interval := time.Duration(config.Config.Outbox.RelayIntervalInMS) * time.Millisecond
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return outboxWorker.NewOutboxWorker(r.service, interval)
+}
@@ -10,15 +10,16 @@ import (
 var Config AppConfig
 
 type AppConfig struct {
-	Port                  int      `json:"port"`
-	AppName               string   `json:"appname"`
-	AppEnv                string   `json:"appEnv"`
-	SignatureKey          string   `json:"signatureKey"`
-	Database              Database `json:"database"`
-	RateLimiterMaxRequest float64  `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond int      `json:"rateLimiterTimeSecond"`
-	JwtSecretKey          string   `json:"jwtSecretKey"`
-	JwtExpirationTime     int      `json:"jwtExpirationTime"`
+	Port                  int               `json:"port"`
+	AppName               string            `json:"appname"`
+	AppEnv                string            `json:"appEnv"`
+	SignatureKey          string            `json:"signatureKey"`
+	Database              Database          `json:"database"`
+	RateLimiterMaxRequest float64           `json:"rateLimiterMaxRequest"`
+	RateLimiterTimeSecond int               `json:"rateLimiterTimeSecond"`
+	JwtSecretKey          string            `json:"jwtSecretKey"`
+	JwtExpirationTime     int               `json:"jwtExpirationTime"`
+	InternalCallers       map[string]string `json:"internalCallers"`
 }
 
 type Database struct {
@@ -21,6 +21,7 @@ type IUserController interface {
 	Update(*gin.Context)
 	GetUserLogin(*gin.Context)
 	GetUserByUUID(*gin.Context)
+	BatchGetUsers(*gin.Context)
 }
 
 func NewUserController(service services.IServiceRegistry) IUserController {
@@ -204,3 +205,54 @@ func (u *UserController) GetUserByUUID(ctx *gin.Context) {
 		Gin:  ctx,
 	})
 }
+
+// BatchGetUsers Controller
+func (u *UserController) BatchGetUsers(ctx *gin.Context) {
+	// gin cannot escape the colon of the custom method, so it is matched as a parameter
+	if ctx.Param("action") != ":batch" {
+		ctx.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	request := &dto.BatchUserRequest{}
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPRes{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	users, err := u.service.GetUser().GetUsersByUUIDs(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPRes{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPRes{
+		Code: http.StatusOK,
+		Data: users,
+		Gin:  ctx,
+	})
+}
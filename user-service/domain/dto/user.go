@@ -7,6 +7,10 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+type BatchUserRequest struct {
+	UUIDs []string `json:"uuids" validate:"required,min=1,max=100,dive,uuid"`
+}
+
 type UserResponse struct {
 	UUID        uuid.UUID `json:"uuid"`
 	Name        string    `json:"name"`
@@ -16,6 +20,12 @@ type UserResponse struct {
 	PhoneNumber string    `json:"phoneNumber"`
 }
 
+// Only the name is shared with the internal callers
+type BatchUserResponse struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
 type LoginResponse struct {
 	User  UserResponse `json:"user"`
 	Token string       `json:"token"`
@@ -68,11 +68,10 @@ func responseUnauthorized(c *gin.Context, message string) {
 	c.Abort()
 }
 
-func validateAPIKey(c *gin.Context) error {
+func validateAPIKey(c *gin.Context, signatureKey string) error {
 	apiKey := c.GetHeader(constants.XApiKey)
 	requestAt := c.GetHeader(constants.XRequestAt)
 	serviceName := c.GetHeader(constants.XServiceName)
-	signatureKey := config.Config.SignatureKey
 
 	validateKey := fmt.Sprintf("%s:%s:%s", serviceName, signatureKey, requestAt)
 	hash := sha256.New()
@@ -132,7 +131,7 @@ func Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		err = validateAPIKey(c)
+		err = validateAPIKey(c, config.Config.SignatureKey)
 		if err != nil {
 			responseUnauthorized(c, err.Error())
 			return
@@ -140,3 +139,21 @@ func Authenticate() gin.HandlerFunc {
 	}
 
 }
+
+// Internal services on the allowlist only, each one signs with its own key
+func AuthenticateInternal() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		signatureKey, ok := config.Config.InternalCallers[c.GetHeader(constants.XServiceName)]
+		if !ok || signatureKey == "" {
+			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		err := validateAPIKey(c, signatureKey)
+		if err != nil {
+			responseUnauthorized(c, err.Error())
+			return
+		}
+		c.Next()
+	}
+}
@@ -22,6 +22,7 @@ type IUserRepository interface {
 	FindByUsername(context.Context, string) (*models.User, error)
 	FindByEmail(context.Context, string) (*models.User, error)
 	FindByUUID(context.Context, string) (*models.User, error)
+	FindByUUIDs(context.Context, []string) ([]models.User, error)
 }
 
 func NewUserRepository(db *gorm.DB) IUserRepository {
@@ -105,3 +106,14 @@ func (r *UserRepository) FindByUUID(ctx context.Context, uuid string) (*models.U
 	}
 	return &user, nil
 }
+
+func (r *UserRepository) FindByUUIDs(ctx context.Context, uuids []string) ([]models.User, error) {
+	var users []models.User
+
+	err := r.db.WithContext(ctx).Where("uuid IN ?", uuids).Find(&users).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSqlError)
+	}
+
+	return users, nil
+}
@@ -27,4 +27,6 @@ func (u *UserRoute) Run() {
 	group.POST("/login", u.controller.GetUserController().Login)
 	group.POST("/register", u.controller.GetUserController().Register)
 	group.PUT("/:uuid", middlewares.Authenticate(), u.controller.GetUserController().Update)
+	// Internal route (called by order-service), the contact data of other users is not public
+	group.POST("/users:action", middlewares.AuthenticateInternal(), u.controller.GetUserController().BatchGetUsers)
 }
@@ -25,6 +25,7 @@ type IUserService interface {
 	Update(context.Context, *dto.UpdateRequest, string) (*dto.UserResponse, error)
 	GetUserLogin(context.Context) (*dto.UserResponse, error)
 	GetUserByUUID(context.Context, string) (*dto.UserResponse, error)
+	GetUsersByUUIDs(context.Context, *dto.BatchUserRequest) ([]dto.BatchUserResponse, error)
 }
 
 type Claims struct {
@@ -253,3 +254,21 @@ func (u *UserService) GetUserByUUID(ctx context.Context, uuid string) (*dto.User
 
 	return &data, nil
 }
+
+// GetUsersByUUIDs returns the users found, unknown UUIDs are left out
+func (u *UserService) GetUsersByUUIDs(ctx context.Context, request *dto.BatchUserRequest) ([]dto.BatchUserResponse, error) {
+	users, err := u.repository.GetUser().FindByUUIDs(ctx, request.UUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]dto.BatchUserResponse, 0, len(users))
+	for _, user := range users {
+		data = append(data, dto.BatchUserResponse{
+			UUID: user.UUID,
+			Name: user.Name,
+		})
+	}
+
+	return data, nil
+}